@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/fardream/go-bcs/bcs"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -55,6 +56,41 @@ func GenerateAuthenticationKey(
 	return sha3.Sum256(allBytes), nil
 }
 
+// authentication key schemes of single key and multi key accounts.
+const (
+	singleKeyScheme = 2
+	multiKeyScheme  = 3
+)
+
+// GenerateSingleKeyAuthenticationKey calculates the authentication key of a [SingleKeyAccount],
+// which is SHA3-256 of the bcs encoded [AnyPublicKey] followed by 0x02.
+func GenerateSingleKeyAuthenticationKey(publicKey *AnyPublicKey) (Address, error) {
+	allBytes, err := bcs.Marshal(publicKey)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return sha3.Sum256(append(allBytes, singleKeyScheme)), nil
+}
+
+// GenerateMultiKeyAuthenticationKey calculates the authentication key of a [MultiKeyAccount],
+// which is SHA3-256 of the bcs encoded [MultiKey] followed by 0x03.
+func GenerateMultiKeyAuthenticationKey(signaturesRequired int, publicKeys ...*AnyPublicKey) (Address, error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MultiKeyMaxSignerCount {
+		return Address{}, fmt.Errorf("public key count %d is not between 1 and %d", len(publicKeys), MultiKeyMaxSignerCount)
+	}
+	if signaturesRequired <= 0 || signaturesRequired > len(publicKeys) {
+		return Address{}, fmt.Errorf("required signature count %d is not between 1 and %d", signaturesRequired, len(publicKeys))
+	}
+
+	allBytes, err := bcs.Marshal(&MultiKey{PublicKeys: publicKeys, SignaturesRequired: uint8(signaturesRequired)})
+	if err != nil {
+		return Address{}, err
+	}
+
+	return sha3.Sum256(append(allBytes, multiKeyScheme)), nil
+}
+
 // LocalAccount contains the private key, public key, and the address.
 type LocalAccount struct {
 	PrivateKey ed25519.PrivateKey
@@ -80,6 +116,20 @@ func NewLocalAccountFromPrivateKey(privateKey *ed25519.PrivateKey) (*LocalAccoun
 	}, nil
 }
 
+// NewLocalAccountWithAddress creates a local account with the private key for the address.
+// This is used when the authentication key of the account has been rotated, and the address is no longer
+// the authentication key calculated from the public key. See [Client.LookupOriginatingAddress] to find the address.
+func NewLocalAccountWithAddress(privateKey *ed25519.PrivateKey, address Address) (*LocalAccount, error) {
+	account, err := NewLocalAccountFromPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Address = address
+
+	return account, nil
+}
+
 func parseHexString(hexString string) ([]byte, error) {
 	return hex.DecodeString(strings.TrimPrefix(hexString, "0x"))
 }
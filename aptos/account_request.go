@@ -84,7 +84,13 @@ type GetAccountResourcesResponse []AccountResource
 //
 // [GetAccountResource]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/operations/get_account_resource
 func (client *Client) GetAccountResource(ctx context.Context, request *GetAccountResourceRequest) (*AptosResponse[GetAccountResourceResponse], error) {
-	return doRequestForType[GetAccountResourceResponse](ctx, client, request)
+	resp, err := doRequestForType[GetAccountResourceResponse](ctx, client, request)
+	if err != nil {
+		return nil, err
+	}
+	fillBcsResourceType(resp, request)
+
+	return resp, nil
 }
 
 type GetAccountResourceRequest struct {
@@ -109,11 +115,17 @@ func (r *GetAccountResourceRequest) PathSegments() ([]string, error) {
 
 type GetAccountResourceResponse struct {
 	*AccountResource `json:",inline"`
+
+	// BcsData is the bcs encoded resource when the response is in bcs.
+	// Data is empty in that case, and Type is taken from the request.
+	BcsData []byte `json:"-"`
 }
 
 // GetAccountResourceWithType get the resource of specified move type, then marshal it into requested type T.
 //
 // This is equivalent of calling [Client.GetAccountResource], then marshal the response into the type.
+// The resource is always requested in json, even if the client is in bcs response mode.
+// See [GetAccountResourceWithBcsType] for the bcs counterpart, and [ModuleABICache.ValidateResourceType] to check the type against the module abi.
 //
 // This is a function since golang doesn't support generic method.
 func GetAccountResourceWithType[T any](ctx context.Context, client *Client, address Address, moveType *MoveStructTag, ledgerVersion uint64) (*T, error) {
@@ -126,7 +138,7 @@ func GetAccountResourceWithType[T any](ctx context.Context, client *Client, addr
 		*(request.LedgerVersion) = JsonUint64(ledgerVersion)
 	}
 
-	resp, err := client.GetAccountResource(ctx, request)
+	resp, err := doRequestForTypeWithAccept[GetAccountResourceResponse](ctx, client, request, ContentTypeJson)
 	if err != nil {
 		return nil, err
 	}
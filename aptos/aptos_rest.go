@@ -1,6 +1,12 @@
 package aptos
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
 
 // AptosRequest defines a helper interface for quickly construct aptos request.
 // The URL query string is generated from [query.Values], and all the fields that should not be included in the query string should be ignored with tag url:"-".
@@ -11,6 +17,11 @@ type AptosRequest interface {
 	HttpMethod() string
 }
 
+// ContentTypeRequest is implemented by requests whose body is not json, for example the bcs encoded [SignedTransaction].
+type ContentTypeRequest interface {
+	ContentType() string
+}
+
 // GetRequest embed this struct for a get request where only path segments are necessary.
 type GetRequest struct{}
 
@@ -39,7 +50,9 @@ func newPathSegmentHolder(segments ...string) *pathSegmentHolder {
 	}
 }
 
-// AptosReponseHeader contains the header information on a successful aptos response
+// AptosReponseHeader contains the header information on a successful aptos response.
+// The raw strings of the X-APTOS headers are kept, and the typed values are parsed from them by [NewAptosResponseHeader].
+// Typed values are zero if the corresponding header is absent.
 type AptosReponseHeader struct {
 	AptosBlockHeight         string
 	AptosChainId             string
@@ -48,6 +61,163 @@ type AptosReponseHeader struct {
 	AptosLedgerTimestampUsec string
 	AptosLedgerVersion       string
 	AptosOldestBlockHeight   string
+
+	BlockHeight         uint64
+	ChainId             uint8
+	Epoch               uint64
+	LedgerOldestVersion uint64
+	LedgerTimestamp     time.Time
+	LedgerVersion       uint64
+	OldestBlockHeight   uint64
+
+	// Endpoint is the rest url that served the request.
+	Endpoint string
+}
+
+// AptosHeaderParseError is returned when a X-APTOS header of the response cannot be parsed.
+type AptosHeaderParseError struct {
+	// Header is the name of the header
+	Header string
+	// Value of the header
+	Value string
+	// Err is the parsing error
+	Err error
+}
+
+var _ error = (*AptosHeaderParseError)(nil)
+
+func (e *AptosHeaderParseError) Error() string {
+	return fmt.Sprintf("failed to parse header %s: %q: %v", e.Header, e.Value, e.Err)
+}
+
+func (e *AptosHeaderParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseHeaderUint parses the header value into an unsigned integer of bitSize. Empty value is parsed to 0.
+func parseHeaderUint(name string, value string, bitSize int) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return 0, &AptosHeaderParseError{Header: name, Value: value, Err: err}
+	}
+
+	return v, nil
+}
+
+// NewAptosResponseHeader reads the X-APTOS headers from the http header, and parses them into typed values.
+// An [AptosHeaderParseError] is returned if any of the header is malformed.
+func NewAptosResponseHeader(endpoint string, header http.Header) (*AptosReponseHeader, error) {
+	h := &AptosReponseHeader{
+		AptosBlockHeight:         header.Get("X-APTOS-BLOCK-HEIGHT"),
+		AptosChainId:             header.Get("X-APTOS-CHAIN-ID"),
+		AptosEpoch:               header.Get("X-APTOS-EPOCH"),
+		AptosLedgerOldestVersion: header.Get("X-APTOS-LEDGER-OLDEST-VERSION"),
+		AptosLedgerTimestampUsec: header.Get("X-APTOS-LEDGER-TIMESTAMPUSEC"),
+		AptosLedgerVersion:       header.Get("X-APTOS-LEDGER-VERSION"),
+		AptosOldestBlockHeight:   header.Get("X-APTOS-OLDEST-BLOCK-HEIGHT"),
+
+		Endpoint: endpoint,
+	}
+
+	var err error
+	if h.BlockHeight, err = parseHeaderUint("X-APTOS-BLOCK-HEIGHT", h.AptosBlockHeight, 64); err != nil {
+		return nil, err
+	}
+	chainId, err := parseHeaderUint("X-APTOS-CHAIN-ID", h.AptosChainId, 8)
+	if err != nil {
+		return nil, err
+	}
+	h.ChainId = uint8(chainId)
+	if h.Epoch, err = parseHeaderUint("X-APTOS-EPOCH", h.AptosEpoch, 64); err != nil {
+		return nil, err
+	}
+	if h.LedgerOldestVersion, err = parseHeaderUint("X-APTOS-LEDGER-OLDEST-VERSION", h.AptosLedgerOldestVersion, 64); err != nil {
+		return nil, err
+	}
+	if h.LedgerVersion, err = parseHeaderUint("X-APTOS-LEDGER-VERSION", h.AptosLedgerVersion, 64); err != nil {
+		return nil, err
+	}
+	if h.OldestBlockHeight, err = parseHeaderUint("X-APTOS-OLDEST-BLOCK-HEIGHT", h.AptosOldestBlockHeight, 64); err != nil {
+		return nil, err
+	}
+	if h.AptosLedgerTimestampUsec != "" {
+		usec, err := parseHeaderUint("X-APTOS-LEDGER-TIMESTAMPUSEC", h.AptosLedgerTimestampUsec, 63)
+		if err != nil {
+			return nil, err
+		}
+		h.LedgerTimestamp = time.UnixMicro(int64(usec))
+	}
+
+	return h, nil
+}
+
+// IsOlderThan checks if the response is from a ledger version older than the given one.
+// A response without ledger version is not considered older.
+func (h *AptosReponseHeader) IsOlderThan(ledgerVersion uint64) bool {
+	return h.AptosLedgerVersion != "" && h.LedgerVersion < ledgerVersion
+}
+
+// StaleLedgerVersionError is returned by [LedgerVersionTracker] when a response is from a ledger version older than one already seen.
+type StaleLedgerVersionError struct {
+	// Seen is the most recent ledger version seen before.
+	Seen uint64
+	// Got is the ledger version of the response.
+	Got uint64
+	// Endpoint that served the stale response.
+	Endpoint string
+}
+
+var _ error = (*StaleLedgerVersionError)(nil)
+
+func (e *StaleLedgerVersionError) Error() string {
+	return fmt.Sprintf("response from %s is at ledger version %d, older than seen version %d", e.Endpoint, e.Got, e.Seen)
+}
+
+// LedgerVersionTracker keeps track of the most recent ledger version seen in responses,
+// and detects responses from an older ledger version.
+// This is useful for read-after-write consistency when the requests can be served by different fullnodes.
+//
+// LedgerVersionTracker is safe for concurrent use, and the zero value is ready to use.
+type LedgerVersionTracker struct {
+	mu     sync.Mutex
+	latest uint64
+}
+
+// Observe checks the ledger version of the response header against the most recent one seen.
+// If the response is older, a [StaleLedgerVersionError] is returned. Otherwise the most recent ledger version is updated.
+func (t *LedgerVersionTracker) Observe(h *AptosReponseHeader) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if h.IsOlderThan(t.latest) {
+		return &StaleLedgerVersionError{Seen: t.latest, Got: h.LedgerVersion, Endpoint: h.Endpoint}
+	}
+	if h.LedgerVersion > t.latest {
+		t.latest = h.LedgerVersion
+	}
+
+	return nil
+}
+
+// SetAtLeast raises the most recent ledger version to at least version, for example the version of a committed transaction.
+func (t *LedgerVersionTracker) SetAtLeast(version uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if version > t.latest {
+		t.latest = version
+	}
+}
+
+// Latest returns the most recent ledger version seen.
+func (t *LedgerVersionTracker) Latest() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.latest
 }
 
 type AptosResponse[T any] struct {
@@ -0,0 +1,83 @@
+package aptos_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func TestNewAptosResponseHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-APTOS-BLOCK-HEIGHT", "400")
+		w.Header().Set("X-APTOS-CHAIN-ID", "4")
+		w.Header().Set("X-APTOS-EPOCH", "2")
+		w.Header().Set("X-APTOS-LEDGER-OLDEST-VERSION", "0")
+		w.Header().Set("X-APTOS-LEDGER-TIMESTAMPUSEC", "1667245093093576")
+		w.Header().Set("X-APTOS-LEDGER-VERSION", "1000")
+		w.Header().Set("X-APTOS-OLDEST-BLOCK-HEIGHT", "10")
+		w.Write([]byte(testLedgerInfoJson))
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	resp, err := client.GetLedgerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get ledger info: %v", err)
+	}
+
+	h := resp.Headers
+	if h.BlockHeight != 400 || h.ChainId != 4 || h.Epoch != 2 || h.LedgerVersion != 1000 || h.OldestBlockHeight != 10 || h.LedgerOldestVersion != 0 {
+		t.Errorf("wrong parsed header: %#v", h)
+	}
+	if !h.LedgerTimestamp.Equal(time.UnixMicro(1667245093093576)) {
+		t.Errorf("want timestamp %v, got %v", time.UnixMicro(1667245093093576), h.LedgerTimestamp)
+	}
+	if h.AptosLedgerVersion != "1000" {
+		t.Errorf("raw header is not kept: %q", h.AptosLedgerVersion)
+	}
+}
+
+func TestNewAptosResponseHeader_Malformed(t *testing.T) {
+	_, err := aptos.NewAptosResponseHeader("", http.Header{"X-Aptos-Chain-Id": []string{"256"}})
+	var parseErr *aptos.AptosHeaderParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("want AptosHeaderParseError, got %v", err)
+	}
+	if parseErr.Header != "X-APTOS-CHAIN-ID" || parseErr.Value != "256" {
+		t.Errorf("wrong parse error: %v", parseErr)
+	}
+}
+
+func TestLedgerVersionTracker(t *testing.T) {
+	var tracker aptos.LedgerVersionTracker
+
+	header := func(version string) *aptos.AptosReponseHeader {
+		return must(aptos.NewAptosResponseHeader("http://localhost", http.Header{"X-Aptos-Ledger-Version": []string{version}}))
+	}
+
+	if err := tracker.Observe(header("100")); err != nil {
+		t.Fatalf("first observe: %v", err)
+	}
+	if err := tracker.Observe(header("120")); err != nil {
+		t.Fatalf("newer version: %v", err)
+	}
+
+	err := tracker.Observe(header("110"))
+	var staleErr *aptos.StaleLedgerVersionError
+	if !errors.As(err, &staleErr) || staleErr.Seen != 120 || staleErr.Got != 110 {
+		t.Fatalf("want stale error, got %v", err)
+	}
+
+	tracker.SetAtLeast(200)
+	if tracker.Latest() != 200 {
+		t.Fatalf("want latest 200, got %d", tracker.Latest())
+	}
+	if !header("150").IsOlderThan(tracker.Latest()) {
+		t.Fatalf("150 should be older than 200")
+	}
+}
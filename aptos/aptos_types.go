@@ -60,3 +60,29 @@ type LedgerInfo struct {
 	BlockHeight         JsonUint64 `json:"block_height"`
 	GitHash             string     `json:"git_hash"`
 }
+
+// MoveOption is the json representation of 0x1::option::Option, which is a vector of zero or one element.
+type MoveOption[T any] struct {
+	Vec []T `json:"vec"`
+}
+
+// Get returns the value and true if the option is set.
+func (o MoveOption[T]) Get() (T, bool) {
+	if len(o.Vec) == 0 {
+		var r T
+		return r, false
+	}
+
+	return o.Vec[0], true
+}
+
+// SimpleMap is the json representation of 0x1::simple_map::SimpleMap, which is a vector of key value pairs.
+type SimpleMap[K any, V any] struct {
+	Data []SimpleMapElement[K, V] `json:"data"`
+}
+
+// SimpleMapElement is a key value pair in [SimpleMap].
+type SimpleMapElement[K any, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
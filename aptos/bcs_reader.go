@@ -0,0 +1,51 @@
+package aptos
+
+import (
+	"io"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// bcsReader decodes values one by one from a reader, keeping track of the number of bytes read and the first error.
+//
+// This is used to decode the types that [bcs.Decoder] cannot handle by reflection, such as enums with nested enums,
+// or values whose encoding depends on information not available in the go type, like entry function arguments.
+type bcsReader struct {
+	r   io.Reader
+	d   *bcs.Decoder
+	n   int
+	err error
+}
+
+func newBcsReader(r io.Reader) *bcsReader {
+	return &bcsReader{r: r, d: bcs.NewDecoder(r)}
+}
+
+// decode decodes v with [bcs.Decoder], v must be a pointer.
+func (b *bcsReader) decode(v any) {
+	if b.err != nil {
+		return
+	}
+	k, err := b.d.Decode(v)
+	b.n += k
+	b.err = err
+}
+
+// uleb128 reads a length or an enum variant.
+func (b *bcsReader) uleb128() int {
+	if b.err != nil {
+		return 0
+	}
+	v, k, err := bcs.ULEB128Decode[int](b.r)
+	b.n += k
+	b.err = err
+
+	return v
+}
+
+// bytes reads a length prefixed byte vector.
+func (b *bcsReader) bytes() []byte {
+	var v []byte
+	b.decode(&v)
+	return v
+}
@@ -0,0 +1,117 @@
+package aptos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// ClientOption_BcsResponse turns on bcs response mode of the client.
+//
+// In bcs response mode, the client sets "Accept: application/x-bcs" header for requests whose response type
+// implements [bcs.Unmarshaler] - currently [Client.GetAccount] and [Client.GetAccountResource] - and decodes the bcs body into the same response types.
+// Bcs responses are smaller and faster to decode than json.
+// View functions can be called with bcs response by [Client.ViewBcs] regardless of the mode.
+type ClientOption_BcsResponse bool
+
+var _ ClientOption = (*ClientOption_BcsResponse)(nil)
+
+func (enabled ClientOption_BcsResponse) SetClientOption(client *Client) *Client {
+	client.bcsResponse = bool(enabled)
+	return client
+}
+
+// moveEventGuid is the guid of an event handle.
+type moveEventGuid struct {
+	CreationNum uint64
+	Address     Address
+}
+
+// moveEventHandle is 0x1::event::EventHandle.
+type moveEventHandle struct {
+	Counter uint64
+	Guid    moveEventGuid
+}
+
+// moveAccount is the bcs layout of 0x1::account::Account, which is returned when the account is requested in bcs.
+type moveAccount struct {
+	AuthenticationKey       []byte
+	SequenceNumber          uint64
+	GuidCreationNum         uint64
+	CoinRegisterEvents      moveEventHandle
+	KeyRotationEvents       moveEventHandle
+	RotationCapabilityOffer []Address // Option<address>
+	SignerCapabilityOffer   []Address // Option<address>
+}
+
+var _ bcs.Unmarshaler = (*GetAccountResponse)(nil)
+
+// UnmarshalBCS decodes the 0x1::account::Account resource returned by the node in bcs.
+func (r *GetAccountResponse) UnmarshalBCS(reader io.Reader) (int, error) {
+	var account moveAccount
+	n, err := bcs.NewDecoder(reader).Decode(&account)
+	if err != nil {
+		return n, err
+	}
+
+	r.SequenceNumber = JsonUint64(account.SequenceNumber)
+	r.AuthenticationKey = "0x" + hex.EncodeToString(account.AuthenticationKey)
+
+	return n, nil
+}
+
+var _ bcs.Unmarshaler = (*GetAccountResourceResponse)(nil)
+
+// UnmarshalBCS keeps the bcs encoded resource in BcsData, since the layout of the resource is unknown.
+func (r *GetAccountResourceResponse) UnmarshalBCS(reader io.Reader) (int, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return len(data), err
+	}
+
+	r.AccountResource = &AccountResource{}
+	r.BcsData = data
+
+	return len(data), nil
+}
+
+// fillBcsResourceType sets the type of the resource from the request, since bcs response doesn't contain the type.
+func fillBcsResourceType(resp *AptosResponse[GetAccountResourceResponse], request *GetAccountResourceRequest) {
+	if resp.Parsed.BcsData != nil && resp.Parsed.AccountResource != nil && resp.Parsed.Type == nil {
+		resp.Parsed.Type = request.Type
+	}
+}
+
+// GetAccountResourceWithBcsType gets the resource of specified move type in bcs, then decodes it into T.
+// T must have the same bcs layout as the move struct.
+//
+// This is the bcs counterpart of [GetAccountResourceWithType], and works regardless of [ClientOption_BcsResponse].
+func GetAccountResourceWithBcsType[T any](ctx context.Context, client *Client, address Address, moveType *MoveStructTag, ledgerVersion uint64) (*T, error) {
+	request := &GetAccountResourceRequest{
+		Address: address,
+		Type:    moveType,
+	}
+	if ledgerVersion > 0 {
+		request.LedgerVersion = new(JsonUint64)
+		*(request.LedgerVersion) = JsonUint64(ledgerVersion)
+	}
+
+	resp, err := doRequestForTypeWithAccept[GetAccountResourceResponse](ctx, client, request, ContentTypeBcs)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Parsed.BcsData == nil {
+		return nil, fmt.Errorf("response for %s is not in bcs", moveType)
+	}
+
+	result := new(T)
+	if _, err := bcs.Unmarshal(resp.Parsed.BcsData, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
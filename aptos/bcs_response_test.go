@@ -0,0 +1,164 @@
+package aptos_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+type testEventHandle struct {
+	Counter     uint64
+	CreationNum uint64
+	Address     aptos.Address
+}
+
+type testMoveAccount struct {
+	AuthenticationKey       []byte
+	SequenceNumber          uint64
+	GuidCreationNum         uint64
+	CoinRegisterEvents      testEventHandle
+	KeyRotationEvents       testEventHandle
+	RotationCapabilityOffer []aptos.Address
+	SignerCapabilityOffer   []aptos.Address
+}
+
+type testCoinStore struct {
+	Value          uint64
+	Frozen         bool
+	DepositEvents  testEventHandle
+	WithdrawEvents testEventHandle
+}
+
+var testBcsAccountAddress = aptos.MustParseAddress("0x767b7442b8547fa5cf50989b9b761760ca6687b83d1c23d3589a5ac8acb50639")
+
+// newTestBcsServer serves an account, a coin store, and a view function, in bcs if requested.
+func newTestBcsServer(tb testing.TB) *httptest.Server {
+	tb.Helper()
+
+	account := bcs.MustMarshal(&testMoveAccount{
+		AuthenticationKey:  testBcsAccountAddress[:],
+		SequenceNumber:     2625,
+		GuidCreationNum:    4,
+		CoinRegisterEvents: testEventHandle{Counter: 1, CreationNum: 0, Address: testBcsAccountAddress},
+		KeyRotationEvents:  testEventHandle{Counter: 0, CreationNum: 1, Address: testBcsAccountAddress},
+	})
+	coinStore := bcs.MustMarshal(&testCoinStore{Value: 100000000})
+	view := bcs.MustMarshal([][]byte{bcs.MustMarshal(uint64(42))})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isBcs := r.Header.Get("Accept") == aptos.ContentTypeBcs
+		if isBcs {
+			w.Header().Set("Content-Type", aptos.ContentTypeBcs)
+		}
+		switch {
+		case r.URL.Path == "/view" && isBcs:
+			w.Write(view)
+		case r.URL.Path == "/view":
+			w.Write([]byte(`["42"]`))
+		case strings.Contains(r.URL.Path, "/resource/") && isBcs:
+			w.Write(coinStore)
+		case strings.Contains(r.URL.Path, "/resource/"):
+			w.Write([]byte(`{"type": "0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>", "data": {"coin": {"value": "100000000"}, "frozen": false}}`))
+		case isBcs:
+			w.Write(account)
+		default:
+			fmt.Fprintf(w, `{"sequence_number": "2625", "authentication_key": "%s"}`, testBcsAccountAddress)
+		}
+	}))
+	tb.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_BcsResponse(t *testing.T) {
+	server := newTestBcsServer(t)
+	client := aptos.ApplyClientOptions(aptos.MustNewClient(aptos.Localnet, server.URL), aptos.ClientOption_BcsResponse(true))
+	ctx := context.Background()
+
+	account, err := client.GetAccount(ctx, &aptos.GetAccountRequest{Address: testBcsAccountAddress})
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	if account.Parsed.SequenceNumber != 2625 || account.Parsed.AuthenticationKey != testBcsAccountAddress.String() {
+		t.Fatalf("wrong account: %#v", account.Parsed)
+	}
+
+	coinStoreType := aptos.GetCoinStoreType(must(aptos.ParseMoveStructTag("0x1::aptos_coin::AptosCoin")))
+	resource, err := client.GetAccountResource(ctx, &aptos.GetAccountResourceRequest{Address: testBcsAccountAddress, Type: coinStoreType})
+	if err != nil {
+		t.Fatalf("failed to get resource: %v", err)
+	}
+	if resource.Parsed.Type.String() != coinStoreType.String() || len(resource.Parsed.BcsData) == 0 {
+		t.Fatalf("wrong resource: %#v", resource.Parsed)
+	}
+
+	coinStore, err := aptos.GetAccountResourceWithBcsType[testCoinStore](ctx, client, testBcsAccountAddress, coinStoreType, 0)
+	if err != nil {
+		t.Fatalf("failed to get coin store: %v", err)
+	}
+	if coinStore.Value != 100000000 {
+		t.Fatalf("want coin value 100000000, got %d", coinStore.Value)
+	}
+
+	// json typed resource is not affected by the bcs mode.
+	if _, err := aptos.GetAccountResourceWithType[aptos.CoinStore](ctx, client, testBcsAccountAddress, coinStoreType, 0); err != nil {
+		t.Fatalf("failed to get json coin store: %v", err)
+	}
+
+	view, err := client.ViewBcs(ctx, &aptos.ViewRequest{Function: aptos.MustNewMoveFunctionTag(aptos.AptosStdAddress, "coin", "balance")})
+	if err != nil {
+		t.Fatalf("failed to call view: %v", err)
+	}
+	var result uint64
+	if len(*view.Parsed) != 1 {
+		t.Fatalf("want 1 return value, got %d", len(*view.Parsed))
+	}
+	if _, err := bcs.Unmarshal((*view.Parsed)[0], &result); err != nil || result != 42 {
+		t.Fatalf("want 42, got %d: %v", result, err)
+	}
+}
+
+func BenchmarkClient_GetAccount(b *testing.B) {
+	server := newTestBcsServer(b)
+	ctx := context.Background()
+	request := &aptos.GetAccountRequest{Address: testBcsAccountAddress}
+
+	for _, isBcs := range []bool{false, true} {
+		client := aptos.ApplyClientOptions(aptos.MustNewClient(aptos.Localnet, server.URL), aptos.ClientOption_BcsResponse(isBcs))
+		b.Run(fmt.Sprintf("bcs=%t", isBcs), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := client.GetAccount(ctx, request); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkClient_View(b *testing.B) {
+	server := newTestBcsServer(b)
+	ctx := context.Background()
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	request := &aptos.ViewRequest{Function: aptos.MustNewMoveFunctionTag(aptos.AptosStdAddress, "coin", "balance")}
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := client.View(ctx, request); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("bcs", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := client.ViewBcs(ctx, request); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
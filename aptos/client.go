@@ -8,19 +8,29 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/fardream/go-bcs/bcs"
 	"github.com/google/go-querystring/query"
 )
 
 // Client for aptos
 type Client struct {
-	restUrl     string
-	gasEstimate *EstimateGasPriceResponse
-	ledgerInfo  *LedgerInfo
-	chainId     uint8
+	restUrl string
+
+	// state is the cached gas estimate, ledger info and chain id, guarded by a mutex.
+	state clientState
 
 	defaultTransactionOptions TransactionOptions
+
+	httpClient  *http.Client
+	middlewares []HttpMiddleware
+	retryPolicy *RetryPolicy
+	bcsResponse bool
+
+	// endpoints is nil unless the client is created by [NewMultiEndpointClient]
+	endpoints *endpointPool
 }
 
 func GetChainIdForNetwork(network Network) uint8 {
@@ -38,6 +48,7 @@ func GetChainIdForNetwork(network Network) uint8 {
 // Values will be taken from the default of the network.
 // URL can be left empty.
 // Client's default option includes expire after 5 minutes and max gas of 20,000.
+// Use [ApplyClientOptions] to customize the http client or add middlewares to the client.
 func NewClient(network Network, restUrl string, transactionOptions ...TransactionOption) (*Client, error) {
 	url := restUrl
 	var err error
@@ -61,6 +72,7 @@ func NewClient(network Network, restUrl string, transactionOptions ...Transactio
 		client.defaultTransactionOptions.SetOption(TransactionOption_MaxGasAmount(20000))
 	}
 
+	client.state.ttl = DefaultClientDataTTL
 	client.SetChainId(GetChainIdForNetwork(network))
 
 	return client, nil
@@ -73,24 +85,40 @@ func MustNewClient(network Network, restUrl string, transactionOptions ...Transa
 
 // SetChainId after client is created.
 func (client *Client) SetChainId(chainId uint8) {
-	client.chainId = chainId
+	client.state.mu.Lock()
+	defer client.state.mu.Unlock()
+	client.state.chainId = chainId
 }
 
 // RefreshData updates gas price estimates and ledger info.
+// It is safe to call concurrently, and the cached data expires after the ttl set by [ClientOption_DataTTL].
 func (client *Client) RefreshData(ctx context.Context) error {
-	if est, err := client.EstimateGasPrice(ctx); err != nil {
+	client.state.refreshMu.Lock()
+	defer client.state.refreshMu.Unlock()
+
+	return client.refreshData(ctx)
+}
+
+// refreshData loads the gas estimate and ledger info, and updates the cache in one go. The caller must hold refreshMu.
+func (client *Client) refreshData(ctx context.Context) error {
+	est, err := client.EstimateGasPrice(ctx)
+	if err != nil {
 		return err
-	} else {
-		client.gasEstimate = est.Parsed
 	}
 
-	if info, err := client.GetLedgerInfo(ctx); err != nil {
+	info, err := client.GetLedgerInfo(ctx)
+	if err != nil {
 		return err
-	} else {
-		client.ledgerInfo = info.Parsed.LedgerInfo
-		client.chainId = info.Parsed.ChainId
 	}
 
+	client.state.mu.Lock()
+	defer client.state.mu.Unlock()
+
+	client.state.gasEstimate = est.Parsed
+	client.state.ledgerInfo = info.Parsed.LedgerInfo
+	client.state.chainId = info.Parsed.ChainId
+	client.state.updatedAt = time.Now()
+
 	return nil
 }
 
@@ -105,6 +133,9 @@ type AptosRestError struct {
 
 	// Message
 	Message string
+
+	// Header of the response
+	Header http.Header
 }
 
 var _ error = (*AptosRestError)(nil)
@@ -113,52 +144,86 @@ func (e *AptosRestError) Error() string {
 	return fmt.Sprintf("http failed: %d %s %s", e.HttpStatusCode, e.Message, e.Body)
 }
 
-func doRequest[TResponse any](ctx context.Context, client *Client, method string, pathSegments []string, queryString string, body []byte) (*AptosResponse[TResponse], error) {
-	fullUrl, err := url.JoinPath(client.restUrl, pathSegments...)
+// Content types of the aptos rest api.
+const (
+	ContentTypeJson = "application/json"
+	ContentTypeBcs  = "application/x-bcs"
+	// ContentTypeSignedTransactionBcs is the content type to submit or simulate a bcs encoded [SignedTransaction].
+	ContentTypeSignedTransactionBcs = "application/x.aptos.signed_transaction+bcs"
+)
+
+// restRequest is the http request constructed from an [AptosRequest].
+type restRequest struct {
+	method       string
+	pathSegments []string
+	queryString  string
+	body         []byte
+	// contentType of the body, default to json if empty.
+	contentType string
+	// accept is the Accept header, empty value doesn't set the header and the response is json.
+	accept string
+}
+
+// doRequest sends the request to the endpoint and parses the response.
+// The response is parsed as bcs if the server returns [ContentTypeBcs], and as json otherwise.
+func doRequest[TResponse any](ctx context.Context, client *Client, endpoint string, request *restRequest) (*AptosResponse[TResponse], error) {
+	fullUrl, err := url.JoinPath(endpoint, request.pathSegments...)
 	if err != nil {
 		return nil, err
 	}
 
-	if queryString != "" {
-		fullUrl = fmt.Sprintf("%s?%s", fullUrl, queryString)
+	if request.queryString != "" {
+		fullUrl = fmt.Sprintf("%s?%s", fullUrl, request.queryString)
 	}
 
-	r, err := http.NewRequestWithContext(ctx, method, fullUrl, bytes.NewReader(body))
+	r, err := http.NewRequestWithContext(ctx, request.method, fullUrl, bytes.NewReader(request.body))
 	if err != nil {
 		return nil, err
 	}
 
-	r.Header.Add("Content-Type", "application/json")
+	contentType := request.contentType
+	if contentType == "" {
+		contentType = ContentTypeJson
+	}
+	r.Header.Add("Content-Type", contentType)
+	if request.accept != "" {
+		r.Header.Set("Accept", request.accept)
+	}
 
-	resp, err := http.DefaultClient.Do(r)
+	resp, err := client.httpDo(r)
 	if err != nil {
 		return nil, err
 	}
 
+	defer resp.Body.Close()
+
 	msg, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read the response body: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode >= 400 {
-		return nil, &AptosRestError{HttpStatusCode: resp.StatusCode, Message: resp.Status, Body: msg}
+		return nil, &AptosRestError{HttpStatusCode: resp.StatusCode, Message: resp.Status, Body: msg, Header: resp.Header}
+	}
+
+	headers, err := NewAptosResponseHeader(endpoint, resp.Header)
+	if err != nil {
+		return nil, err
 	}
 
 	res := &AptosResponse[TResponse]{
 		RawData: msg,
 		Parsed:  new(TResponse),
-		Headers: &AptosReponseHeader{},
+		Headers: headers,
 	}
 
-	// headers
-	res.Headers.AptosBlockHeight = resp.Header.Get("X-APTOS-BLOCK-HEIGHT")
-	res.Headers.AptosChainId = resp.Header.Get("X-APTOS-CHAIN-ID")
-	res.Headers.AptosEpoch = resp.Header.Get("X-APTOS-EPOCH")
-	res.Headers.AptosLedgerOldestVersion = resp.Header.Get("X-APTOS-LEDGER-OLDEST-VERSION")
-	res.Headers.AptosLedgerTimestampUsec = resp.Header.Get("X-APTOS-LEDGER-TIMESTAMPUSEC")
-	res.Headers.AptosLedgerVersion = resp.Header.Get("X-APTOS-LEDGER-VERSION")
-	res.Headers.AptosOldestBlockHeight = resp.Header.Get("X-APTOS-OLDEST-BLOCK-HEIGHT")
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), ContentTypeBcs) {
+		if _, err := bcs.Unmarshal(msg, res.Parsed); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bcs response: %w", err)
+		}
+
+		return res, nil
+	}
 
 	err = json.Unmarshal(msg, res.Parsed)
 	if err != nil {
@@ -168,8 +233,8 @@ func doRequest[TResponse any](ctx context.Context, client *Client, method string
 	return res, nil
 }
 
-// doRequestForType takes an AptosRequest, construct the request and pass on to [doRequest].
-func doRequestForType[TResponse any](ctx context.Context, client *Client, request AptosRequest) (*AptosResponse[TResponse], error) {
+// newRestRequest constructs the path, query string, and body from the AptosRequest.
+func newRestRequest(request AptosRequest) (*restRequest, error) {
 	pathSegments, err := request.PathSegments()
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct path: %w", err)
@@ -179,12 +244,46 @@ func doRequestForType[TResponse any](ctx context.Context, client *Client, reques
 	if err != nil {
 		return nil, err
 	}
-	queryString := queryV.Encode()
 
 	body, err := request.Body()
 	if err != nil {
 		return nil, err
 	}
 
-	return doRequest[TResponse](ctx, client, request.HttpMethod(), pathSegments, queryString, body)
+	r := &restRequest{
+		method:       request.HttpMethod(),
+		pathSegments: pathSegments,
+		queryString:  queryV.Encode(),
+		body:         body,
+	}
+	if withContentType, ok := request.(ContentTypeRequest); ok {
+		r.contentType = withContentType.ContentType()
+	}
+
+	return r, nil
+}
+
+// doRequestForType takes an AptosRequest, construct the request and pass on to [doRequest].
+// Idempotent requests (see [IsIdempotentRequest]) are retried according to the retry policy of the client.
+// If the client is in bcs response mode (see [ClientOption_BcsResponse]) and TResponse implements [bcs.Unmarshaler], bcs response is requested.
+func doRequestForType[TResponse any](ctx context.Context, client *Client, request AptosRequest) (*AptosResponse[TResponse], error) {
+	accept := ""
+	if client.bcsResponse {
+		if _, ok := any(new(TResponse)).(bcs.Unmarshaler); ok {
+			accept = ContentTypeBcs
+		}
+	}
+
+	return doRequestForTypeWithAccept[TResponse](ctx, client, request, accept)
+}
+
+// doRequestForTypeWithAccept is [doRequestForType] with the Accept header set explicitly.
+func doRequestForTypeWithAccept[TResponse any](ctx context.Context, client *Client, request AptosRequest, accept string) (*AptosResponse[TResponse], error) {
+	r, err := newRestRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	r.accept = accept
+
+	return doRequestWithRetry[TResponse](ctx, client, IsIdempotentRequest(request), r)
 }
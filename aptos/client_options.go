@@ -0,0 +1,81 @@
+package aptos
+
+import "net/http"
+
+// ClientOption configures a [Client].
+// Apply them with [ApplyClientOptions].
+type ClientOption interface {
+	SetClientOption(*Client) *Client
+}
+
+// ApplyClientOptions apply multiple options to the client in order.
+func ApplyClientOptions(client *Client, options ...ClientOption) *Client {
+	for _, opt := range options {
+		opt.SetClientOption(client)
+	}
+
+	return client
+}
+
+// HttpDoFunc sends an http request and returns the response. It has the same signature as [http.Client.Do].
+type HttpDoFunc func(*http.Request) (*http.Response, error)
+
+// HttpMiddleware wraps a [HttpDoFunc] with additional logic, such as header injection, logging, metrics, or tracing.
+// The middleware can modify the request before calling next, and inspect or modify the response after next returns.
+type HttpMiddleware func(next HttpDoFunc) HttpDoFunc
+
+// ClientOption_HttpClient sets the [http.Client] used by the client to send requests.
+// This can be used to set up timeouts, proxies, tls settings, or connection pooling.
+// If the client is nil, [http.DefaultClient] is used.
+type ClientOption_HttpClient struct {
+	*http.Client
+}
+
+var _ ClientOption = (*ClientOption_HttpClient)(nil)
+
+func (opt ClientOption_HttpClient) SetClientOption(client *Client) *Client {
+	client.httpClient = opt.Client
+	return client
+}
+
+// ClientOption_HttpMiddlewares appends the middlewares to the middleware chain of the client.
+// The first middleware in the chain is the outermost one, which sees the request first and the response last.
+type ClientOption_HttpMiddlewares []HttpMiddleware
+
+var _ ClientOption = (*ClientOption_HttpMiddlewares)(nil)
+
+func (opt ClientOption_HttpMiddlewares) SetClientOption(client *Client) *Client {
+	client.middlewares = append(client.middlewares, opt...)
+	return client
+}
+
+// NewHeaderMiddleware creates a [HttpMiddleware] that sets the headers on every outgoing request.
+// Headers already present on the request are overwritten.
+func NewHeaderMiddleware(header http.Header) HttpMiddleware {
+	return func(next HttpDoFunc) HttpDoFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			for k, values := range header {
+				r.Header.Del(k)
+				for _, v := range values {
+					r.Header.Add(k, v)
+				}
+			}
+			return next(r)
+		}
+	}
+}
+
+// httpDo sends the request through the middleware chain and the http client.
+func (client *Client) httpDo(r *http.Request) (*http.Response, error) {
+	httpClient := client.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	do := HttpDoFunc(httpClient.Do)
+	for i := len(client.middlewares) - 1; i >= 0; i-- {
+		do = client.middlewares[i](do)
+	}
+
+	return do(r)
+}
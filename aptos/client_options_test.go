@@ -0,0 +1,87 @@
+package aptos_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testLedgerInfoJson = `{
+  "chain_id": 4,
+  "epoch": "2",
+  "ledger_version": "1000",
+  "oldest_ledger_version": "0",
+  "ledger_timestamp": "1667245093093576",
+  "node_role": "full_node",
+  "oldest_block_height": "0",
+  "block_height": "400",
+  "git_hash": "abcdef"
+}`
+
+func newTestLedgerInfoServer(t *testing.T, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if check != nil {
+			check(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-APTOS-CHAIN-ID", "4")
+		w.Header().Set("X-APTOS-LEDGER-VERSION", "1000")
+		w.Write([]byte(testLedgerInfoJson))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestApplyClientOptions_Middlewares(t *testing.T) {
+	var gotKey string
+	server := newTestLedgerInfoServer(t, func(r *http.Request) {
+		gotKey = r.Header.Get("X-Api-Key")
+	})
+
+	var calls []string
+	recorder := func(name string) aptos.HttpMiddleware {
+		return func(next aptos.HttpDoFunc) aptos.HttpDoFunc {
+			return func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, name+"-request")
+				resp, err := next(r)
+				calls = append(calls, name+"-response")
+				return resp, err
+			}
+		}
+	}
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_HttpClient{Client: &http.Client{Timeout: 10 * time.Second}},
+		aptos.ClientOption_HttpMiddlewares{
+			recorder("outer"),
+			aptos.NewHeaderMiddleware(http.Header{"X-Api-Key": []string{"secret"}}),
+			recorder("inner"),
+		},
+	)
+
+	resp, err := client.GetLedgerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get ledger info: %v", err)
+	}
+
+	if resp.Parsed.LedgerVersion != 1000 {
+		t.Errorf("want ledger version 1000, got %d", resp.Parsed.LedgerVersion)
+	}
+
+	if gotKey != "secret" {
+		t.Errorf("header is not injected, got %q", gotKey)
+	}
+
+	wantCalls := []string{"outer-request", "inner-request", "inner-response", "outer-response"}
+	if !cmp.Equal(calls, wantCalls) {
+		t.Errorf("want calls %v, got %v", wantCalls, calls)
+	}
+}
@@ -0,0 +1,153 @@
+package aptos
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultClientDataTTL is the default time the gas estimate and ledger info cached by a [Client] stay fresh.
+const DefaultClientDataTTL = time.Minute
+
+// GasPriority selects which gas price from [EstimateGasPriceResponse] is used when filling a transaction.
+type GasPriority uint8
+
+const (
+	// GasPriority_Normal uses [EstimateGasPriceResponse].GasEstimate
+	GasPriority_Normal GasPriority = iota
+	// GasPriority_Deprioritized uses [EstimateGasPriceResponse].DeprioritizedGasEstimate
+	GasPriority_Deprioritized
+	// GasPriority_Prioritized uses [EstimateGasPriceResponse].PrioritizedGasEstimate
+	GasPriority_Prioritized
+)
+
+// GasPrice returns the gas price for the priority. If the estimate for the priority is missing (zero), the normal estimate is used.
+func (est *EstimateGasPriceResponse) GasPrice(priority GasPriority) uint64 {
+	var price uint
+	switch priority {
+	case GasPriority_Deprioritized:
+		price = est.DeprioritizedGasEstimate
+	case GasPriority_Prioritized:
+		price = est.PrioritizedGasEstimate
+	}
+	if price == 0 {
+		price = est.GasEstimate
+	}
+
+	return uint64(price)
+}
+
+// clientState is the data cached by the client: gas estimate, ledger info and chain id.
+type clientState struct {
+	mu sync.RWMutex
+
+	gasEstimate *EstimateGasPriceResponse
+	ledgerInfo  *LedgerInfo
+	chainId     uint8
+	updatedAt   time.Time
+
+	ttl         time.Duration
+	gasPriority GasPriority
+
+	// refreshMu makes sure only one refresh is in flight.
+	refreshMu sync.Mutex
+}
+
+// isFresh checks if the gas estimate and ledger info are loaded and not expired. A non-positive ttl never expires.
+func (s *clientState) isFresh() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.gasEstimate == nil || s.ledgerInfo == nil {
+		return false
+	}
+
+	return s.ttl <= 0 || time.Since(s.updatedAt) < s.ttl
+}
+
+// ClientOption_DataTTL sets how long the gas estimate and ledger info cached by the client stay fresh.
+// Once expired, they are reloaded the next time they are needed. Zero or negative duration means the data never expires.
+// Default is [DefaultClientDataTTL].
+type ClientOption_DataTTL time.Duration
+
+var _ ClientOption = (*ClientOption_DataTTL)(nil)
+
+func (ttl ClientOption_DataTTL) SetClientOption(client *Client) *Client {
+	client.state.mu.Lock()
+	defer client.state.mu.Unlock()
+	client.state.ttl = time.Duration(ttl)
+
+	return client
+}
+
+// ClientOption_GasPriority sets which gas estimate is used when the client fills the gas unit price of a transaction.
+type ClientOption_GasPriority GasPriority
+
+var _ ClientOption = (*ClientOption_GasPriority)(nil)
+
+func (priority ClientOption_GasPriority) SetClientOption(client *Client) *Client {
+	client.state.mu.Lock()
+	defer client.state.mu.Unlock()
+	client.state.gasPriority = GasPriority(priority)
+
+	return client
+}
+
+// refreshIfStale calls [Client.RefreshData] if the cached data is missing or expired.
+// Concurrent callers wait for a single refresh instead of each sending their own requests.
+func (client *Client) refreshIfStale(ctx context.Context) error {
+	if client.state.isFresh() {
+		return nil
+	}
+
+	client.state.refreshMu.Lock()
+	defer client.state.refreshMu.Unlock()
+
+	if client.state.isFresh() {
+		return nil
+	}
+
+	return client.refreshData(ctx)
+}
+
+// GetGasPrice returns the cached gas price for the priority, refreshing the data if it is missing or expired.
+func (client *Client) GetGasPrice(ctx context.Context, priority GasPriority) (uint64, error) {
+	if err := client.refreshIfStale(ctx); err != nil {
+		return 0, err
+	}
+
+	client.state.mu.RLock()
+	defer client.state.mu.RUnlock()
+
+	return client.state.gasEstimate.GasPrice(priority), nil
+}
+
+// GetCachedLedgerInfo returns the cached ledger info, refreshing the data if it is missing or expired.
+func (client *Client) GetCachedLedgerInfo(ctx context.Context) (LedgerInfo, error) {
+	if err := client.refreshIfStale(ctx); err != nil {
+		return LedgerInfo{}, err
+	}
+
+	client.state.mu.RLock()
+	defer client.state.mu.RUnlock()
+
+	return *client.state.ledgerInfo, nil
+}
+
+// StartDataRefresh calls [Client.RefreshData] every interval in the background until the context is done,
+// so the transactions are filled with fresh gas price without waiting for the refresh.
+// Errors of the refresh are ignored, and the data will be refreshed on demand once it expires.
+func (client *Client) StartDataRefresh(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			client.RefreshData(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,89 @@
+package aptos_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func newTestGasServer(t *testing.T, gasCount *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/estimate_gas_price"):
+			atomic.AddInt32(gasCount, 1)
+			w.Write([]byte(`{"deprioritized_gas_estimate": 100, "gas_estimate": 150, "prioritized_gas_estimate": 300}`))
+		default:
+			w.Write([]byte(testLedgerInfoJson))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_FillTransactionData_Concurrent(t *testing.T) {
+	var gasCount int32
+	server := newTestGasServer(t, &gasCount)
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_GasPriority(aptos.GasPriority_Prioritized),
+		aptos.ClientOption_DataTTL(time.Hour),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx := &aptos.Transaction{SequenceNumber: 1}
+			if err := client.FillTransactionData(context.Background(), tx, false); err != nil {
+				t.Errorf("failed to fill transaction: %v", err)
+				return
+			}
+			if tx.GasUnitPrice != 300 || tx.ChainId != 4 {
+				t.Errorf("want gas price 300 and chain id 4, got %d and %d", tx.GasUnitPrice, tx.ChainId)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if gasCount != 1 {
+		t.Fatalf("want gas estimated once, got %d", gasCount)
+	}
+}
+
+func TestClient_DataTTL(t *testing.T) {
+	var gasCount int32
+	server := newTestGasServer(t, &gasCount)
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_DataTTL(time.Millisecond),
+	)
+
+	price, err := client.GetGasPrice(context.Background(), aptos.GasPriority_Deprioritized)
+	if err != nil {
+		t.Fatalf("failed to get gas price: %v", err)
+	}
+	if price != 100 {
+		t.Fatalf("want deprioritized gas price 100, got %d", price)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := client.GetGasPrice(context.Background(), aptos.GasPriority_Normal); err != nil {
+		t.Fatalf("failed to get gas price: %v", err)
+	}
+	if gasCount != 2 {
+		t.Fatalf("want expired data reloaded, got %d gas estimations", gasCount)
+	}
+}
@@ -70,7 +70,7 @@ To see a list of all coins that are known, check "ls-known" command.
 			args.endpoint, _, err = aptos.GetDefaultEndpoint(args.network)
 			orPanic(err)
 		}
-		account := getOrPanic(config.GetLocalAccount())
+		account := getOrPanic(args.getLocalAccount(config))
 
 		auxConfig := getOrPanic(aptos.GetAuxClientConfig(args.network))
 
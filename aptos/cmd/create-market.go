@@ -64,7 +64,7 @@ To buy 0.5 BTC at a price of 19000, price should be 19,000,000,000, and the quan
 			args.endpoint, _, err = aptos.GetDefaultEndpoint(args.network)
 			orPanic(err)
 		}
-		account := getOrPanic(config.GetLocalAccount())
+		account := getOrPanic(args.getLocalAccount(config))
 
 		auxConfig := getOrPanic(aptos.GetAuxClientConfig(args.network))
 
@@ -63,7 +63,7 @@ If no fee bps is specified, it will be set to 30.
 			args.endpoint, _, err = aptos.GetDefaultEndpoint(args.network)
 			orPanic(err)
 		}
-		account := getOrPanic(config.GetLocalAccount())
+		account := getOrPanic(args.getLocalAccount(config))
 
 		auxConfig := getOrPanic(aptos.GetAuxClientConfig(args.network))
 
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+// keystorePassphraseEnv is the environment variable for the keystore passphrase.
+const keystorePassphraseEnv = "APTOS_KEYSTORE_PASSPHRASE"
+
+const keystoreLongDescription = `Keystore
+
+Private keys can be stored in an encrypted keystore file instead of the aptos configuration file.
+The passphrase of the keystore is read from the file specified by --passphrase-file, or the environment variable ` + keystorePassphraseEnv + `,
+or prompted from the standard input.
+`
+
+func getDefaultKeystoreLocation() string {
+	home := getOrPanic(os.UserHomeDir())
+
+	return path.Join(home, ".aptos", "keystore.json")
+}
+
+// keystoreArgs are the location of the keystore and the passphrase.
+type keystoreArgs struct {
+	keystore       string
+	passphraseFile string
+}
+
+func (args *keystoreArgs) SetCmd(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&args.keystore, "keystore", args.keystore, "encrypted keystore file to load the private key from.")
+	cmd.MarkPersistentFlagFilename("keystore", "json")
+	cmd.PersistentFlags().StringVar(&args.passphraseFile, "passphrase-file", args.passphraseFile, "file containing the passphrase of the keystore.")
+}
+
+// readPassphrase reads the passphrase from the file, the environment variable, or the standard input.
+func (args *keystoreArgs) readPassphrase() []byte {
+	if args.passphraseFile != "" {
+		return []byte(strings.TrimRight(string(getOrPanic(os.ReadFile(args.passphraseFile))), "\r\n"))
+	}
+	if passphrase, ok := os.LookupEnv(keystorePassphraseEnv); ok {
+		return []byte(passphrase)
+	}
+
+	fmt.Fprintf(os.Stderr, "passphrase for %s: ", args.keystore)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		orPanic(fmt.Errorf("failed to read passphrase: %w", err))
+	}
+
+	return []byte(strings.TrimRight(line, "\r\n"))
+}
+
+// load reads the keystore, and unlocks it if unlock is true.
+func (args *keystoreArgs) load(unlock bool) *aptos.Keystore {
+	ks := getOrPanic(aptos.LoadKeystoreFile(args.keystore))
+	if unlock {
+		orPanic(ks.Unlock(args.readPassphrase()))
+	}
+
+	return ks
+}
+
+// loadOrCreate reads and unlocks the keystore, or creates a new one if the file doesn't exist.
+func (args *keystoreArgs) loadOrCreate() *aptos.Keystore {
+	if IsFileExists(args.keystore) {
+		return args.load(true)
+	}
+
+	return getOrPanic(aptos.NewKeystore(args.readPassphrase()))
+}
+
+func GetKeystoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keystore",
+		Short: "import, export, list, and rename the keys in an encrypted keystore",
+		Args:  cobra.NoArgs,
+		Long:  keystoreLongDescription,
+	}
+
+	cmd.AddCommand(
+		getKeystoreImportCmd(),
+		getKeystoreExportCmd(),
+		getKeystoreListCmd(),
+		getKeystoreRenameCmd(),
+	)
+
+	return cmd
+}
+
+func newKeystoreArgs(cmd *cobra.Command) *keystoreArgs {
+	args := &keystoreArgs{keystore: getDefaultKeystoreLocation()}
+	args.SetCmd(cmd)
+
+	return args
+}
+
+func getKeystoreImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import label",
+		Short: "import a private key, a profile from the aptos configuration file, or mnemonic into the keystore",
+		Args:  cobra.ExactArgs(1),
+		Long: `Import a key into the keystore with the label. The keystore is created if it doesn't exist.
+
+Exactly one of --private-key, --from-profile, or --mnemonic must be provided.
+
+` + keystoreLongDescription,
+	}
+
+	args := newKeystoreArgs(cmd)
+
+	privateKey := ""
+	fromProfile := ""
+	mnemonic := ""
+	derivationPath := aptos.PetraPath
+	address := aptos.Address{}
+
+	cmd.Flags().StringVar(&privateKey, "private-key", privateKey, "hex encoded ed25519 private key")
+	cmd.Flags().StringVar(&fromProfile, "from-profile", fromProfile, "profile in the aptos configuration file")
+	cmd.Flags().StringVar(&mnemonic, "mnemonic", mnemonic, "mnemonic codes separated by space")
+	cmd.Flags().StringVar(&derivationPath, "derivation-path", derivationPath, "bip32 derivation path for the mnemonic")
+	cmd.Flags().Var(&address, "address", "address of the account if the key has been rotated")
+	cmd.MarkFlagsMutuallyExclusive("private-key", "from-profile", "mnemonic")
+
+	cmd.Run = func(_ *cobra.Command, labels []string) {
+		var account *aptos.LocalAccount
+		derivedPath := ""
+
+		switch {
+		case privateKey != "":
+			account = getOrPanic(aptos.NewLocalAccountFromPrivateKey(getOrPanic(aptos.NewPrivateKeyFromHexString(privateKey))))
+		case fromProfile != "":
+			configFile, _ := getConfigFileLocation()
+			configs := getOrPanic(aptos.ParseAptosConfigFile(getOrPanic(os.ReadFile(configFile))))
+			config, ok := configs.Profiles[fromProfile]
+			if !ok {
+				orPanic(fmt.Errorf("cannot find profile %s in config file %s", fromProfile, configFile))
+			}
+			account = getOrPanic(config.GetLocalAccount())
+		case mnemonic != "":
+			seed := getOrPanic(bip39.NewSeedWithErrorChecking(mnemonic, ""))
+			account = getOrPanic(aptos.NewLocalAccountFromPrivateKey(getOrPanic(aptos.Bip32DerivePath(derivationPath, seed, aptos.HardenedOffset))))
+			derivedPath = derivationPath
+		default:
+			orPanic(fmt.Errorf("one of --private-key, --from-profile, or --mnemonic is required"))
+		}
+
+		if cmd.Flags().Changed("address") {
+			account.Address = address
+		}
+
+		ks := args.loadOrCreate()
+		orPanic(ks.Add(labels[0], account, derivedPath))
+		orPanic(os.MkdirAll(path.Dir(args.keystore), 0o700))
+		orPanic(ks.SaveFile(args.keystore))
+
+		fmt.Printf("imported %s with address %s\n", labels[0], account.Address)
+	}
+
+	return cmd
+}
+
+func getKeystoreExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export label",
+		Short: "print out the private key in the keystore, or write it to a profile in the aptos configuration file",
+		Args:  cobra.ExactArgs(1),
+		Long:  keystoreLongDescription,
+	}
+
+	args := newKeystoreArgs(cmd)
+
+	outputFile := ""
+	profile := ""
+	cmd.Flags().StringVarP(&outputFile, "output", "o", outputFile, "output the key into a configuration file. if the file already exists, it will be backed up")
+	cmd.Flags().StringVarP(&profile, "profile", "p", profile, "profile name in the configuration file")
+	cmd.MarkFlagFilename("output", "yml", "yaml")
+	cmd.MarkFlagsRequiredTogether("output", "profile")
+
+	cmd.Run = func(_ *cobra.Command, labels []string) {
+		account := getOrPanic(args.load(true).Get(labels[0]))
+
+		if outputFile == "" {
+			config := &aptos.Config{}
+			config.SetKey(account)
+			fmt.Printf("private key: %s\n", config.PrivateKey)
+			fmt.Printf("public key: %s\n", config.PublicKey)
+			fmt.Printf("address: %s\n", account.Address)
+			return
+		}
+
+		configs := aptos.NewConfigFile()
+		if IsFileExists(outputFile) {
+			configs = getOrPanic(aptos.ParseAptosConfigFile(getOrPanic(os.ReadFile(outputFile))))
+		}
+		if configs.Profiles == nil {
+			configs.Profiles = make(map[string]*aptos.Config)
+		}
+
+		config, ok := configs.Profiles[profile]
+		if !ok {
+			restUrl, faucetUrl, _ := aptos.GetDefaultEndpoint(aptos.Network(profile))
+			config = &aptos.Config{RestUrl: restUrl, FaucetUrl: faucetUrl}
+		}
+		config.SetKey(account)
+		configs.Profiles[profile] = config
+
+		OverwriteConfig(configs, outputFile)
+	}
+
+	return cmd
+}
+
+func getKeystoreListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "list the keys in the keystore",
+		Args:  cobra.NoArgs,
+	}
+
+	args := newKeystoreArgs(cmd)
+
+	cmd.Run = func(*cobra.Command, []string) {
+		ks := args.load(false)
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"label", "address", "public key", "derivation path"})
+		for _, entry := range ks.Entries {
+			table.Append([]string{entry.Label, entry.Address.String(), entry.PublicKey, entry.DerivationPath})
+		}
+		table.Render()
+	}
+
+	return cmd
+}
+
+func getKeystoreRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename label new-label",
+		Short: "rename a key in the keystore",
+		Args:  cobra.ExactArgs(2),
+	}
+
+	args := newKeystoreArgs(cmd)
+
+	cmd.Run = func(_ *cobra.Command, labels []string) {
+		ks := args.load(false)
+		orPanic(ks.Rename(labels[0], labels[1]))
+		orPanic(ks.SaveFile(args.keystore))
+	}
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func GetListHDAccountsCmd() *cobra.Command {
+	const longDescription = `List the addresses derived from mnemonic codes.
+
+Accounts are derived by the paths m/44'/637'/index'/0'/0', where index starts from --start.
+With --scan, the accounts are checked on chain, and the accounts that exist on chain are listed
+until --gap-limit consecutive accounts are not found.`
+
+	cmd := &cobra.Command{
+		Use:   "ls-hd-accounts",
+		Short: "list the addresses derived from mnemonic codes",
+		Args:  cobra.NoArgs,
+		Long:  longDescription,
+	}
+
+	mnemonicCodes := []string{}
+	start := uint32(0)
+	count := uint32(10)
+	scan := false
+	gapLimit := uint32(20)
+	network := aptos.Mainnet
+	endpoint := ""
+
+	cmd.Flags().StringArrayVarP(&mnemonicCodes, "mnemonic", "m", mnemonicCodes, "mnemonic codes")
+	cmd.MarkFlagRequired("mnemonic")
+	cmd.Flags().Uint32Var(&start, "start", start, "first account index")
+	cmd.Flags().Uint32VarP(&count, "count", "c", count, "number of accounts to list")
+	cmd.Flags().BoolVar(&scan, "scan", scan, "list the accounts that exist on chain")
+	cmd.Flags().Uint32Var(&gapLimit, "gap-limit", gapLimit, "stop scanning after this many consecutive accounts are not found on chain")
+	cmd.Flags().VarP(&network, "network", "n", "network to scan")
+	cmd.Flags().StringVarP(&endpoint, "endpoint", "u", endpoint, "endpoint for the rest api, default to the one provided by aptos labs.")
+
+	cmd.Run = func(*cobra.Command, []string) {
+		wallet := getOrPanic(aptos.NewHDWallet(strings.Join(mnemonicCodes, " "), ""))
+
+		table := tablewriter.NewWriter(os.Stdout)
+
+		if !scan {
+			table.SetHeader([]string{"Index", "Path", "Address"})
+			for index := start; index < start+count; index++ {
+				account := getOrPanic(wallet.DeriveAccount(index))
+				table.Append([]string{strconv.FormatUint(uint64(index), 10), aptos.AptosDerivationPath(index), account.Address.String()})
+			}
+			table.Render()
+			return
+		}
+
+		if endpoint == "" {
+			var err error
+			endpoint, _, err = aptos.GetDefaultEndpoint(network)
+			orPanic(err)
+		}
+		client := getOrPanic(aptos.NewClient(network, endpoint))
+
+		accounts := getOrPanic(wallet.ScanAccounts(context.Background(), client, start, gapLimit))
+		table.SetHeader([]string{"Index", "Path", "Address", "Sequence Number"})
+		for _, account := range accounts {
+			table.Append([]string{
+				strconv.FormatUint(uint64(account.Index), 10),
+				account.Path,
+				account.Address.String(),
+				strconv.FormatUint(account.SequenceNumber, 10),
+			})
+		}
+		table.Render()
+	}
+
+	return cmd
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func GetMultisigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisig",
+		Short: "list and act on the proposals of an on-chain multisig account",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		getMultisigListCmd(),
+		getMultisigVoteCmd(true),
+		getMultisigVoteCmd(false),
+		getMultisigExecuteCmd(),
+		getMultisigExecuteRejectedCmd(),
+	)
+
+	return cmd
+}
+
+// multisigArgs are the shared args and the address of the multisig account.
+type multisigArgs struct {
+	*SharedArgs
+	multisigAddress aptos.Address
+}
+
+func newMultisigArgs(cmd *cobra.Command) *multisigArgs {
+	args := &multisigArgs{SharedArgs: NewSharedArgs()}
+	args.SetCmd(cmd)
+	cmd.PersistentFlags().VarP(&args.multisigAddress, "multisig-address", "a", "address of the multisig account")
+	cmd.MarkPersistentFlagRequired("multisig-address")
+
+	return args
+}
+
+// getClient returns the client for the profile, and the local account of the profile if withAccount is true.
+func (args *multisigArgs) getClient(cmd *cobra.Command, withAccount bool) (*aptos.Client, *aptos.LocalAccount) {
+	args.UpdateProfileForCmd(cmd)
+
+	configFile, _ := getConfigFileLocation()
+	configs := getOrPanic(aptos.ParseAptosConfigFile(getOrPanic(os.ReadFile(configFile))))
+	if configs.Profiles == nil {
+		orPanic(fmt.Errorf("empty configuration at %s", configFile))
+	}
+
+	config, ok := configs.Profiles[args.profile]
+	if !ok {
+		orPanic(fmt.Errorf("cannot find profile %s in config file %s", args.profile, configFile))
+	}
+
+	if args.endpoint == "" && config.RestUrl != "" {
+		args.endpoint = config.RestUrl
+	}
+	if args.endpoint == "" {
+		var err error
+		args.endpoint, _, err = aptos.GetDefaultEndpoint(args.network)
+		orPanic(err)
+	}
+
+	client := getOrPanic(aptos.NewClient(args.network, args.endpoint))
+	if !withAccount {
+		return client, nil
+	}
+
+	return client, getOrPanic(args.getLocalAccount(config))
+}
+
+// run signs and submits the transaction in bcs, or simulates it.
+func (args *multisigArgs) run(cmd *cobra.Command, newTransaction func(sender aptos.Address, options ...aptos.TransactionOption) *aptos.Transaction) {
+	client, account := args.getClient(cmd, true)
+
+	tx := newTransaction(account.Address, aptos.TransactionOption_MaxGasAmount(args.maxGasAmount))
+	orPanic(client.FillTransactionData(context.Background(), tx, false))
+
+	if args.simulate {
+		resp := getOrPanic(
+			client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{
+				SignedTransaction: getOrPanic(aptos.SignTransactionForSimulation(account, tx)),
+			}),
+		)
+		fmt.Println(string(resp.RawData))
+		return
+	}
+
+	resp := getOrPanic(client.SubmitSignedTransactionBCS(context.Background(), getOrPanic(aptos.SignTransaction(account, tx))))
+	spew.Dump(getOrPanic(client.WaitForTransaction(context.Background(), resp.Parsed.Hash)))
+}
+
+func getMultisigListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "list the pending proposals of the multisig account",
+		Args:  cobra.NoArgs,
+		Long:  commonLongDescription,
+	}
+
+	args := newMultisigArgs(cmd)
+
+	cmd.Run = func(*cobra.Command, []string) {
+		client, _ := args.getClient(cmd, false)
+
+		account := getOrPanic(client.GetMultisigAccount(context.Background(), args.multisigAddress))
+		fmt.Printf("owners: %v, signatures required: %d\n", account.Owners, account.NumSignaturesRequired)
+
+		txs := getOrPanic(client.GetMultisigPendingTransactions(context.Background(), args.multisigAddress))
+		for _, tx := range txs {
+			approvals, rejections := tx.VoteCounts()
+			fmt.Printf("sequence number: %d, creator: %s, approvals: %d, rejections: %d\n", tx.SequenceNumber, tx.Creator, approvals, rejections)
+			if hash, ok := tx.PayloadHash.Get(); ok {
+				fmt.Printf("  payload hash: %s\n", hash)
+				continue
+			}
+			payload := getOrPanic(tx.DecodePayload())
+			fmt.Printf("  function: %s, type arguments: %v, %d arguments\n", payload.Function, payload.TypeArguments, len(payload.Arguments))
+		}
+	}
+
+	return cmd
+}
+
+func getMultisigVoteCmd(approve bool) *cobra.Command {
+	use := "reject"
+	if approve {
+		use = "approve"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: fmt.Sprintf("%s a proposal of the multisig account", use),
+		Args:  cobra.NoArgs,
+		Long:  commonLongDescription,
+	}
+
+	args := newMultisigArgs(cmd)
+
+	var sequenceNumber uint64
+	cmd.PersistentFlags().Uint64VarP(&sequenceNumber, "sequence-number", "q", sequenceNumber, "sequence number of the proposal")
+	cmd.MarkPersistentFlagRequired("sequence-number")
+
+	cmd.Run = func(*cobra.Command, []string) {
+		args.run(cmd, func(sender aptos.Address, options ...aptos.TransactionOption) *aptos.Transaction {
+			if approve {
+				return aptos.MultisigAccount_ApproveTransaction(sender, args.multisigAddress, sequenceNumber, options...)
+			}
+			return aptos.MultisigAccount_RejectTransaction(sender, args.multisigAddress, sequenceNumber, options...)
+		})
+	}
+
+	return cmd
+}
+
+func getMultisigExecuteCmd() *cobra.Command {
+	const longDescription = `Execute the next proposal of the multisig account, which must have enough approvals.
+
+Only proposals with the full payload stored on chain can be executed by this command.
+
+` + commonLongDescription
+
+	cmd := &cobra.Command{
+		Use:   "execute",
+		Short: "execute the next approved proposal of the multisig account",
+		Args:  cobra.NoArgs,
+		Long:  longDescription,
+	}
+
+	args := newMultisigArgs(cmd)
+
+	cmd.Run = func(*cobra.Command, []string) {
+		args.run(cmd, func(sender aptos.Address, options ...aptos.TransactionOption) *aptos.Transaction {
+			return aptos.NewMultisigExecutionTransaction(sender, args.multisigAddress, nil, options...)
+		})
+	}
+
+	return cmd
+}
+
+func getMultisigExecuteRejectedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute-rejected",
+		Short: "remove the next rejected proposal of the multisig account",
+		Args:  cobra.NoArgs,
+		Long:  commonLongDescription,
+	}
+
+	args := newMultisigArgs(cmd)
+
+	cmd.Run = func(*cobra.Command, []string) {
+		args.run(cmd, func(sender aptos.Address, options ...aptos.TransactionOption) *aptos.Transaction {
+			return aptos.MultisigAccount_ExecuteRejectedTransaction(sender, args.multisigAddress, options...)
+		})
+	}
+
+	return cmd
+}
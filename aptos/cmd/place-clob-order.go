@@ -75,7 +75,7 @@ To buy 0.5 BTC at a price of 19000 USDC, price should be 19,000,000,000, and the
 				args.endpoint, _, err = aptos.GetDefaultEndpoint(args.network)
 				orPanic(err)
 			}
-			account := getOrPanic(config.GetLocalAccount())
+			account := getOrPanic(args.getLocalAccount(config))
 
 			auxConfig := getOrPanic(aptos.GetAuxClientConfig(args.network))
 
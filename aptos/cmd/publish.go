@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func GetPublishCmd() *cobra.Command {
+	const longDescription = `Publish or upgrade a move package.
+
+The package must be compiled with metadata first:
+
+  aptos move compile --save-metadata --named-addresses <name>=<address>
+
+The compiled package is loaded from build/<package-name> under the package directory.
+If package name is not specified, the only package in the build directory is used.
+
+The max gas amount is estimated by simulating the transaction, and --max-gas-amount is only used in simulation.
+
+` + commonLongDescription
+
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "publish a move package",
+		Args:  cobra.NoArgs,
+		Long:  longDescription,
+	}
+
+	packageDir := "."
+	packageName := ""
+
+	args := NewSharedArgs()
+	args.SetCmd(cmd)
+
+	cmd.PersistentFlags().StringVarP(&packageDir, "package-dir", "d", packageDir, "directory of the package, which contains Move.toml")
+	cmd.PersistentFlags().StringVarP(&packageName, "package-name", "p", packageName, "name of the package to publish")
+
+	cmd.Run = func(*cobra.Command, []string) {
+		args.UpdateProfileForCmd(cmd)
+
+		configFile, _ := getConfigFileLocation()
+		configs := getOrPanic(aptos.ParseAptosConfigFile(getOrPanic(os.ReadFile(configFile))))
+		if configs.Profiles == nil {
+			orPanic(fmt.Errorf("empty configuration at %s", configFile))
+		}
+
+		config, ok := configs.Profiles[args.profile]
+		if !ok {
+			orPanic(fmt.Errorf("cannot find profile %s in config file %s", args.profile, configFile))
+		}
+
+		if args.endpoint == "" && config.RestUrl != "" {
+			args.endpoint = config.RestUrl
+		}
+		if args.endpoint == "" {
+			var err error
+			args.endpoint, _, err = aptos.GetDefaultEndpoint(args.network)
+			orPanic(err)
+		}
+		account := getOrPanic(args.getLocalAccount(config))
+
+		client := getOrPanic(aptos.NewClient(args.network, args.endpoint))
+
+		buildDir := getOrPanic(aptos.FindMovePackageBuildDir(packageDir, packageName))
+		pkg := getOrPanic(aptos.LoadMovePackage(buildDir))
+
+		fmt.Printf("publishing package %s with %d modules to %s\n", pkg.Metadata.Name, len(pkg.Modules), account.Address)
+
+		if args.simulate {
+			tx := aptos.NewPublishPackageTransaction(account.Address, pkg, aptos.TransactionOption_MaxGasAmount(args.maxGasAmount))
+			orPanic(client.FillTransactionData(context.Background(), tx, false))
+			resp := getOrPanic(
+				client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{
+					SignedTransaction: getOrPanic(aptos.SignTransactionForSimulation(account, tx)),
+				}),
+			)
+			fmt.Println(string(resp.RawData))
+			return
+		}
+
+		spew.Dump(getOrPanic(client.PublishPackage(context.Background(), account, pkg, false)))
+	}
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func GetRemoteSignerCmd() *cobra.Command {
+	const longDescription = `Run a remote signing service with the key of the profile or the keystore.
+
+Transactions are signed for clients using aptos.RemoteSigner, if they call the allowed functions,
+their gas amounts, gas unit prices, fees, and expirations are within the limits, and they are for the chain.
+If no function is specified, all the functions are allowed.
+
+Requests are authenticated by the secret read from --secret-file, which must be shared with the clients.
+The secret must be at least 16 bytes.
+
+` + commonLongDescription
+
+	cmd := &cobra.Command{
+		Use:   "remote-signer",
+		Short: "run a remote signing service",
+		Args:  cobra.NoArgs,
+		Long:  longDescription,
+	}
+
+	args := NewSharedArgs()
+	args.SetCmd(cmd)
+
+	listen := ""
+	unixSocket := ""
+	secretFile := ""
+	allowedFunctions := []string{}
+	maxGasLimit := uint64(0)
+	maxGasUnitPrice := uint64(0)
+	maxTransactionFee := uint64(0)
+	chainId := uint8(0)
+	maxExpiration := time.Duration(0)
+
+	cmd.Flags().StringVar(&listen, "listen", listen, "tcp address to listen on, for example 127.0.0.1:8090")
+	cmd.Flags().StringVar(&unixSocket, "unix-socket", unixSocket, "unix socket to listen on")
+	cmd.MarkFlagsMutuallyExclusive("listen", "unix-socket")
+	cmd.Flags().StringVar(&secretFile, "secret-file", secretFile, "file containing the secret shared with the clients")
+	cmd.MarkFlagRequired("secret-file")
+	cmd.Flags().StringArrayVar(&allowedFunctions, "allow-function", allowedFunctions, "entry function allowed to be called, can be specified multiple times")
+	cmd.Flags().Uint64Var(&maxGasLimit, "max-gas-limit", maxGasLimit, "max gas amount allowed for a transaction, 0 means no limit")
+	cmd.Flags().Uint64Var(&maxGasUnitPrice, "max-gas-unit-price", maxGasUnitPrice, "max gas unit price in octas allowed for a transaction, 0 means no limit")
+	cmd.Flags().Uint64Var(&maxTransactionFee, "max-transaction-fee", maxTransactionFee, "max of max gas amount times gas unit price in octas allowed for a transaction, 0 means no limit")
+	cmd.Flags().Uint8Var(&chainId, "chain-id", chainId, "chain id the transactions must be for, 0 allows any chain")
+	cmd.Flags().DurationVar(&maxExpiration, "max-expiration", maxExpiration, "max duration from now to the expiration of a transaction, 0 means no limit")
+
+	cmd.Run = func(*cobra.Command, []string) {
+		args.UpdateProfileForCmd(cmd)
+
+		configFile, _ := getConfigFileLocation()
+		configs := getOrPanic(aptos.ParseAptosConfigFile(getOrPanic(os.ReadFile(configFile))))
+		if configs.Profiles == nil {
+			orPanic(fmt.Errorf("empty configuration at %s", configFile))
+		}
+
+		config, ok := configs.Profiles[args.profile]
+		if !ok {
+			orPanic(fmt.Errorf("cannot find profile %s in config file %s", args.profile, configFile))
+		}
+
+		account := getOrPanic(args.getLocalAccount(config))
+
+		policy := &aptos.RemoteSignerPolicy{
+			MaxGasAmount:      maxGasLimit,
+			MaxGasUnitPrice:   maxGasUnitPrice,
+			MaxTransactionFee: maxTransactionFee,
+			ChainId:           chainId,
+			MaxExpiration:     maxExpiration,
+		}
+		for _, function := range allowedFunctions {
+			policy.AllowedFunctions = append(policy.AllowedFunctions, getOrPanic(aptos.ParseModuleFunctionTag(function)))
+		}
+
+		secret := []byte(strings.TrimRight(string(getOrPanic(os.ReadFile(secretFile))), "\r\n"))
+		server := getOrPanic(aptos.NewRemoteSignerServer(account, secret, policy))
+		server.OnRequest = func(request *aptos.RemoteSignRequest, tx *aptos.Transaction) error {
+			log.Printf("sign request for sequence number %d, simulation: %t, metadata: %v", tx.SequenceNumber, request.Simulation, request.Metadata)
+			return nil
+		}
+
+		var listener net.Listener
+		switch {
+		case unixSocket != "":
+			listener = getOrPanic(net.Listen("unix", unixSocket))
+		case listen != "":
+			listener = getOrPanic(net.Listen("tcp", listen))
+		default:
+			orPanic(fmt.Errorf("one of --listen or --unix-socket is required"))
+		}
+
+		log.Printf("signing for %s on %s", account.Address, listener.Addr())
+		httpServer := &http.Server{
+			Handler:           server,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      10 * time.Second,
+		}
+		orPanic(httpServer.Serve(listener))
+	}
+
+	return cmd
+}
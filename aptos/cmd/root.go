@@ -29,6 +29,11 @@ func GetRootCmd() *cobra.Command {
 		GetAmmAddLiquidityCmd(),
 		GetAmmSwapCmd(),
 		GetAmmRemoveLiquidityCmd(),
+		GetPublishCmd(),
+		GetMultisigCmd(),
+		GetKeystoreCmd(),
+		GetRemoteSignerCmd(),
+		GetListHDAccountsCmd(),
 	)
 
 	return cmd
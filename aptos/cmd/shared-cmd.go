@@ -24,6 +24,9 @@ The profile still needs to be configured properly, although private key is not n
 
 --max-gas-amount can be used to adjust the gas. Note the aptos network requires the max gas amount * gas unit price to be
 available in the sending account even for simulations.
+
+--keystore loads the private key from an encrypted keystore instead of the profile. The key is the one labeled by --key-label,
+or the profile name if the label is unset. See the keystore command for details.
 `
 
 // SharedArgs for commands
@@ -33,6 +36,9 @@ type SharedArgs struct {
 	endpoint     string
 	maxGasAmount uint64
 	simulate     bool
+
+	keystoreArgs
+	keyLabel string
 }
 
 func NewSharedArgs() *SharedArgs {
@@ -51,6 +57,8 @@ func (args *SharedArgs) SetCmd(cmd *cobra.Command) {
 	cmd.PersistentFlags().Uint64VarP(&args.maxGasAmount, "max-gas-amount", "m", args.maxGasAmount, "max gas amount - make sure the account has enough aptos liquidity.")
 	cmd.PersistentFlags().StringVarP(&args.profile, "profile", "k", args.profile, "aptos profile to use. if a network is selected but this is unset, will use profile with that name.")
 	cmd.PersistentFlags().BoolVarP(&args.simulate, "simulate", "s", args.simulate, "simulate the transaction")
+	args.keystoreArgs.SetCmd(cmd)
+	cmd.PersistentFlags().StringVar(&args.keyLabel, "key-label", args.keyLabel, "label of the key in the keystore. default to the profile name.")
 }
 
 type SharedArgsWithBaseQuoteCoins struct {
@@ -78,3 +86,17 @@ func (args *SharedArgs) UpdateProfileForCmd(cmd *cobra.Command) {
 		args.profile = string(args.network)
 	}
 }
+
+// getLocalAccount loads the account from the keystore if --keystore is set, otherwise from the profile.
+func (args *SharedArgs) getLocalAccount(config *aptos.Config) (*aptos.LocalAccount, error) {
+	if args.keystore == "" {
+		return config.GetLocalAccount()
+	}
+
+	label := args.keyLabel
+	if label == "" {
+		label = args.profile
+	}
+
+	return args.keystoreArgs.load(true).Get(label)
+}
@@ -0,0 +1,247 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultMaxLedgerVersionLag is the default max number of versions an endpoint can lag behind the freshest endpoint
+// before it is skipped.
+const DefaultMaxLedgerVersionLag = 1000
+
+// EndpointStatus is the health status of an endpoint of a multi-endpoint [Client].
+type EndpointStatus struct {
+	// Url of the rest api.
+	Url string
+	// Healthy is false if the last request or probe to this endpoint failed.
+	Healthy bool
+	// LedgerVersion is the latest ledger version seen from this endpoint.
+	LedgerVersion uint64
+	// LastChecked is the time the status is last updated.
+	LastChecked time.Time
+	// LastError is the error from last failed request or probe.
+	LastError error
+}
+
+// endpointPool keeps track of the health of the endpoints.
+type endpointPool struct {
+	mu sync.RWMutex
+
+	endpoints []*EndpointStatus
+	maxLag    uint64
+}
+
+// NewMultiEndpointClient creates a new client that routes the requests to multiple endpoints.
+//
+// The requests are sent to the healthy endpoint with the most recent ledger version. Endpoints whose ledger version lags
+// behind the freshest one by more than [DefaultMaxLedgerVersionLag] (see [ClientOption_MaxLedgerVersionLag]) are skipped.
+// When a request fails with a retryable error (see [IsRetryableError]), the endpoint is marked unhealthy and the
+// request is sent to the next endpoint if it is idempotent (see [IsIdempotentRequest]). Transaction submissions are
+// not sent to another endpoint, they are retried by the [RetryPolicy], which checks if the transaction has landed first.
+//
+// The endpoints' health is updated by [Client.CheckEndpoints], which can be run periodically with [Client.StartEndpointHealthCheck].
+// The endpoint that served a request is reported in [AptosReponseHeader].
+func NewMultiEndpointClient(network Network, restUrls []string, transactionOptions ...TransactionOption) (*Client, error) {
+	if len(restUrls) == 0 {
+		return nil, fmt.Errorf("no endpoint is provided")
+	}
+
+	client, err := NewClient(network, restUrls[0], transactionOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.endpoints = &endpointPool{
+		maxLag: DefaultMaxLedgerVersionLag,
+	}
+	for _, url := range restUrls {
+		client.endpoints.endpoints = append(client.endpoints.endpoints, &EndpointStatus{Url: url, Healthy: true})
+	}
+
+	return client, nil
+}
+
+// ClientOption_MaxLedgerVersionLag sets the max number of versions an endpoint can lag behind the freshest endpoint in a multi-endpoint client.
+// This is ignored if the client has only one endpoint.
+type ClientOption_MaxLedgerVersionLag uint64
+
+var _ ClientOption = (*ClientOption_MaxLedgerVersionLag)(nil)
+
+func (lag ClientOption_MaxLedgerVersionLag) SetClientOption(client *Client) *Client {
+	if client.endpoints != nil {
+		client.endpoints.mu.Lock()
+		defer client.endpoints.mu.Unlock()
+		client.endpoints.maxLag = uint64(lag)
+	}
+
+	return client
+}
+
+// EndpointStatuses returns a snapshot of the statuses of the endpoints.
+// For a single endpoint client, nil is returned.
+func (client *Client) EndpointStatuses() []EndpointStatus {
+	if client.endpoints == nil {
+		return nil
+	}
+
+	client.endpoints.mu.RLock()
+	defer client.endpoints.mu.RUnlock()
+
+	return mapSlices(client.endpoints.endpoints, func(e *EndpointStatus) EndpointStatus { return *e })
+}
+
+// CheckEndpoints probes all the endpoints with [Client.GetLedgerInfo] and updates their statuses.
+// An error is returned if none of the endpoints is healthy.
+func (client *Client) CheckEndpoints(ctx context.Context) error {
+	if client.endpoints == nil {
+		return nil
+	}
+
+	urls := mapSlices(client.EndpointStatuses(), func(e EndpointStatus) string { return e.Url })
+
+	var wg sync.WaitGroup
+	for _, url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			resp, err := doRequest[GetLedgerInfoResponse](ctx, client, url, &restRequest{method: http.MethodGet})
+			switch {
+			case ctx.Err() != nil:
+				// the probe is cancelled, the health is unknown.
+			case err != nil:
+				client.endpoints.markFailure(url, err)
+			default:
+				client.endpoints.markSuccess(url, uint64(resp.Parsed.LedgerVersion))
+			}
+		}(url)
+	}
+	wg.Wait()
+
+	for _, status := range client.EndpointStatuses() {
+		if status.Healthy {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("none of the endpoints is healthy")
+}
+
+// StartEndpointHealthCheck runs [Client.CheckEndpoints] every interval until the context is done.
+func (client *Client) StartEndpointHealthCheck(ctx context.Context, interval time.Duration) {
+	if client.endpoints == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			client.CheckEndpoints(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// candidates returns the endpoints to try in order.
+// Healthy endpoints within the lag are sorted by ledger version descending, endpoints that are not probed yet
+// (ledger version is 0) are always within the lag. The unhealthy or lagging endpoints are appended
+// in their original order, so there is always an endpoint to fail over to.
+func (pool *endpointPool) candidates() []string {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var maxVersion uint64
+	for _, e := range pool.endpoints {
+		if e.Healthy && e.LedgerVersion > maxVersion {
+			maxVersion = e.LedgerVersion
+		}
+	}
+
+	var eligible, others []*EndpointStatus
+	for _, e := range pool.endpoints {
+		if e.Healthy && (e.LedgerVersion == 0 || e.LedgerVersion+pool.maxLag >= maxVersion) {
+			eligible = append(eligible, e)
+		} else {
+			others = append(others, e)
+		}
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].LedgerVersion > eligible[j].LedgerVersion
+	})
+
+	return mapSlices(append(eligible, others...), func(e *EndpointStatus) string { return e.Url })
+}
+
+func (pool *endpointPool) find(url string) *EndpointStatus {
+	for _, e := range pool.endpoints {
+		if e.Url == url {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (pool *endpointPool) markFailure(url string, err error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if e := pool.find(url); e != nil {
+		e.Healthy = false
+		e.LastError = err
+		e.LastChecked = time.Now()
+	}
+}
+
+func (pool *endpointPool) markSuccess(url string, ledgerVersion uint64) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if e := pool.find(url); e != nil {
+		e.Healthy = true
+		e.LastError = nil
+		e.LastChecked = time.Now()
+		if ledgerVersion > e.LedgerVersion {
+			e.LedgerVersion = ledgerVersion
+		}
+	}
+}
+
+// doRequestWithFailover sends the request to the endpoint of the client. If the client has multiple endpoints,
+// an idempotent request is sent to the next endpoint when it fails with a retryable error.
+// Other requests, such as transaction submissions, are only sent to one endpoint, and left to the retry logic of the caller.
+func doRequestWithFailover[TResponse any](ctx context.Context, client *Client, idempotent bool, request *restRequest) (*AptosResponse[TResponse], error) {
+	if client.endpoints == nil {
+		return doRequest[TResponse](ctx, client, client.restUrl, request)
+	}
+
+	candidates := client.endpoints.candidates()
+	if !idempotent {
+		candidates = candidates[:1]
+	}
+
+	var lastErr error
+	for _, endpoint := range candidates {
+		resp, err := doRequest[TResponse](ctx, client, endpoint, request)
+		if err == nil {
+			client.endpoints.markSuccess(endpoint, resp.Headers.LedgerVersion)
+			return resp, nil
+		}
+		if !IsRetryableError(err) {
+			return nil, err
+		}
+		client.endpoints.markFailure(endpoint, err)
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
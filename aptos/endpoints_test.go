@@ -0,0 +1,152 @@
+package aptos_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func newTestEndpoint(t *testing.T, status int, ledgerVersion uint64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("X-APTOS-LEDGER-VERSION", fmt.Sprint(ledgerVersion))
+		fmt.Fprintf(w, `{"chain_id": 4, "ledger_version": "%d"}`, ledgerVersion)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestMultiEndpointClient_Failover(t *testing.T) {
+	down := newTestEndpoint(t, http.StatusServiceUnavailable, 0)
+	up := newTestEndpoint(t, http.StatusOK, 100)
+
+	client, err := aptos.NewMultiEndpointClient(aptos.Localnet, []string{down.URL, up.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.GetLedgerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get ledger info: %v", err)
+	}
+	if resp.Headers.Endpoint != up.URL {
+		t.Fatalf("want served by %s, got %s", up.URL, resp.Headers.Endpoint)
+	}
+
+	statuses := client.EndpointStatuses()
+	if statuses[0].Healthy || !statuses[1].Healthy {
+		t.Fatalf("wrong health status: %#v", statuses)
+	}
+}
+
+func TestMultiEndpointClient_SkipLagging(t *testing.T) {
+	lagging := newTestEndpoint(t, http.StatusOK, 100)
+	fresh := newTestEndpoint(t, http.StatusOK, 5000)
+
+	client, err := aptos.NewMultiEndpointClient(aptos.Localnet, []string{lagging.URL, fresh.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	aptos.ApplyClientOptions(client, aptos.ClientOption_MaxLedgerVersionLag(1000))
+
+	if err := client.CheckEndpoints(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.GetLedgerInfo(context.Background())
+		if err != nil {
+			t.Fatalf("failed to get ledger info: %v", err)
+		}
+		if resp.Headers.Endpoint != fresh.URL {
+			t.Fatalf("want served by %s, got %s", fresh.URL, resp.Headers.Endpoint)
+		}
+	}
+}
+
+func TestMultiEndpointClient_FailoverAfterSuccess(t *testing.T) {
+	var failing int32
+	serving := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-APTOS-LEDGER-VERSION", "5000")
+		fmt.Fprint(w, `{"chain_id": 4, "ledger_version": "5000"}`)
+	}))
+	defer serving.Close()
+	backup := newTestEndpoint(t, http.StatusOK, 5001)
+
+	client, err := aptos.NewMultiEndpointClient(aptos.Localnet, []string{serving.URL, backup.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.GetLedgerInfo(context.Background())
+	if err != nil || resp.Headers.Endpoint != serving.URL {
+		t.Fatalf("first request should be served by %s: %v", serving.URL, err)
+	}
+
+	// the backup is not probed yet, but is still a candidate after the serving endpoint fails.
+	atomic.StoreInt32(&failing, 1)
+	resp, err = client.GetLedgerInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get ledger info: %v", err)
+	}
+	if resp.Headers.Endpoint != backup.URL {
+		t.Fatalf("want served by %s, got %s", backup.URL, resp.Headers.Endpoint)
+	}
+}
+
+func TestMultiEndpointClient_NoFailoverForSubmission(t *testing.T) {
+	signedTx := testSignedTransaction1(t)
+
+	down := newTestEndpoint(t, http.StatusServiceUnavailable, 0)
+	var submitCount int32
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&submitCount, 1)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"type": "pending_transaction", "hash": "0x01"}`)
+	}))
+	defer up.Close()
+
+	client, err := aptos.NewMultiEndpointClient(aptos.Localnet, []string{down.URL, up.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := &aptos.SubmitTransactionRequest{
+		Transaction: signedTx.Transaction,
+		Signature: aptos.SingleSignature{
+			Type:      aptos.Ed25519SignatureType,
+			PublicKey: "0xff0ef0e5910c05c24551f135485a1fcb3bced53e20996bd26bfc23aa8816756c",
+			Signature: "0x05c4586e2b47fa4d81a2fab4b6ac7975fb4d2a410d507bada48b8a0adff14c1dd18a9c8342076a133e7f6b358efba0bff777c3cc78f1131f5c5d617329b8200a",
+		},
+	}
+	if _, err := client.SubmitTransaction(context.Background(), request); err == nil {
+		t.Fatalf("submission to the unavailable endpoint should fail without a retry policy")
+	}
+	if atomic.LoadInt32(&submitCount) != 0 {
+		t.Fatalf("submission should not be sent to another endpoint")
+	}
+
+	// the failed endpoint is moved to the back, the next submission goes to the healthy one.
+	if _, err := client.SubmitTransaction(context.Background(), request); err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+	if atomic.LoadInt32(&submitCount) != 1 {
+		t.Fatalf("want 1 submission, got %d", submitCount)
+	}
+}
@@ -3,6 +3,7 @@ package aptos
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/fardream/go-bcs/bcs"
@@ -20,6 +21,8 @@ type EntryFunctionPayload struct {
 // all bcs byte vectors.
 // This means the values are first bcs serialized to vector of bytes.
 // Then the vector of bytes are serialized.
+//
+// Use [NewEntryFunctionArg] to create the argument from a go value.
 type EntryFunctionArg struct {
 	Bool    *bool
 	Uint8   *uint8
@@ -27,7 +30,28 @@ type EntryFunctionArg struct {
 	Uint128 *bcs.Uint128
 	Address *Address
 	Signer  *struct{}
-	Vector  *[]byte
+	// Vector is vector<u8>, which is a 0x prefixed hex string in json.
+	Vector *[]byte
+
+	Uint16  *uint16
+	Uint32  *uint32
+	Uint256 *Uint256
+	// String is move's 0x1::string::String, same as vector<u8> in bcs, but a string in json.
+	String *string
+	// Elements is a vector of other types, including nested vectors. The elements must be of the same type.
+	Elements *[]*EntryFunctionArg
+	// Option is move's 0x1::option::Option.
+	Option *EntryFunctionArgOption
+
+	// Raw is the bcs encoded value of an argument whose type is unknown,
+	// for example an argument decoded from a bcs encoded transaction.
+	// Raw cannot be marshaled to json.
+	Raw *[]byte
+}
+
+// EntryFunctionArgOption is the value of an 0x1::option::Option argument, nil Value is none.
+type EntryFunctionArgOption struct {
+	Value *EntryFunctionArg
 }
 
 var (
@@ -36,36 +60,72 @@ var (
 	_ json.Unmarshaler = (*EntryFunctionArg)(nil)
 )
 
-func marshalWithByteLength[T any](v T) ([]byte, error) {
-	if firstBytes, err := bcs.Marshal(v); err != nil {
-		return nil, err
-	} else {
-		return bcs.Marshal(firstBytes)
-	}
-}
-
 // MarshalBCS customizes bcs Marshal for [EntryFunctionArg].
 // This method first bcs serialize the non-nil value, then
 // serialize the resulted byte vector (simply prepending the length with ULEB128 encoding).
 func (m EntryFunctionArg) MarshalBCS() ([]byte, error) {
+	valueBytes, err := m.marshalValueBCS()
+	if err != nil {
+		return nil, err
+	}
+
+	return bcs.Marshal(valueBytes)
+}
+
+// marshalValueBCS serializes the value without the length prefix, which is also how the value is serialized as an element of a vector.
+func (m EntryFunctionArg) marshalValueBCS() ([]byte, error) {
 	switch {
 	case m.Bool != nil:
-		return marshalWithByteLength(m.Bool)
+		return bcs.Marshal(m.Bool)
 	case m.Uint8 != nil:
-		return marshalWithByteLength(m.Uint8)
+		return bcs.Marshal(m.Uint8)
+	case m.Uint16 != nil:
+		return bcs.Marshal(m.Uint16)
+	case m.Uint32 != nil:
+		return bcs.Marshal(m.Uint32)
 	case m.Uint64 != nil:
-		return marshalWithByteLength(m.Uint64)
+		return bcs.Marshal(m.Uint64)
 	case m.Uint128 != nil:
-		return marshalWithByteLength(m.Uint128)
+		return bcs.Marshal(m.Uint128)
+	case m.Uint256 != nil:
+		return bcs.Marshal(m.Uint256)
 	case m.Address != nil:
-		return marshalWithByteLength(m.Address)
+		return bcs.Marshal(m.Address)
 	case m.Vector != nil:
-		return bcs.Marshal(m.Vector)
+		return bcs.Marshal(*m.Vector)
+	case m.String != nil:
+		return bcs.Marshal(*m.String)
+	case m.Elements != nil:
+		return marshalElementsBCS(*m.Elements)
+	case m.Option != nil:
+		if m.Option.Value == nil {
+			return marshalElementsBCS(nil)
+		}
+		return marshalElementsBCS([]*EntryFunctionArg{m.Option.Value})
+	case m.Raw != nil:
+		return append([]byte{}, *m.Raw...), nil
 	default:
 		return nil, fmt.Errorf("unset arg")
 	}
 }
 
+// marshalElementsBCS serializes the elements as a vector.
+func marshalElementsBCS(elements []*EntryFunctionArg) ([]byte, error) {
+	r := bcs.ULEB128Encode(len(elements))
+	for i, element := range elements {
+		if element == nil {
+			return nil, fmt.Errorf("element %d is nil", i)
+		}
+		elementBytes, err := element.marshalValueBCS()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal element %d: %w", i, err)
+		}
+		r = append(r, elementBytes...)
+	}
+
+	return r, nil
+}
+
 // reset sets all values to nil.
 func (m *EntryFunctionArg) reset() {
 	m.Bool = nil
@@ -75,8 +135,17 @@ func (m *EntryFunctionArg) reset() {
 	m.Address = nil
 	m.Signer = nil
 	m.Vector = nil
+	m.Uint16 = nil
+	m.Uint32 = nil
+	m.Uint256 = nil
+	m.String = nil
+	m.Elements = nil
+	m.Option = nil
+	m.Raw = nil
 }
 
+// MarshalJSON encodes the argument the same way as the node: u8, u16, u32 are numbers, u64, u128, and u256 are strings,
+// vector<u8> is a 0x prefixed hex string, other vectors are arrays, and option is {"vec": []}.
 func (m EntryFunctionArg) MarshalJSON() ([]byte, error) {
 	switch {
 	case m.Bool != nil:
@@ -85,17 +154,60 @@ func (m EntryFunctionArg) MarshalJSON() ([]byte, error) {
 		return json.Marshal(*m.Address)
 	case m.Uint8 != nil:
 		return json.Marshal(*m.Uint8)
+	case m.Uint16 != nil:
+		return json.Marshal(*m.Uint16)
+	case m.Uint32 != nil:
+		return json.Marshal(*m.Uint32)
 	case m.Uint64 != nil:
 		return json.Marshal(*m.Uint64)
 	case m.Uint128 != nil:
 		return json.Marshal(*m.Uint128)
+	case m.Uint256 != nil:
+		return json.Marshal(*m.Uint256)
 	case m.Vector != nil:
-		return json.Marshal(*m.Vector)
+		return json.Marshal(prefixedHexString(*m.Vector))
+	case m.String != nil:
+		return json.Marshal(*m.String)
+	case m.Elements != nil:
+		return json.Marshal(*m.Elements)
+	case m.Option != nil:
+		elements := make([]*EntryFunctionArg, 0, 1)
+		if m.Option.Value != nil {
+			elements = append(elements, m.Option.Value)
+		}
+		return json.Marshal(map[string][]*EntryFunctionArg{"vec": elements})
+	case m.Raw != nil:
+		return nil, fmt.Errorf("raw argument of unknown type cannot be marshaled to json: 0x%x", *m.Raw)
 	}
 
 	return nil, fmt.Errorf("unsupported: %v", m)
 }
 
+// decodeEntryFunctionPayload decodes the entry function payload from bcs.
+// Since the types of the arguments are unknown, the arguments are decoded as [EntryFunctionArg].Raw.
+func decodeEntryFunctionPayload(b *bcsReader) *EntryFunctionPayload {
+	r := &EntryFunctionPayload{
+		Function:      &MoveFunctionTag{},
+		TypeArguments: make([]*MoveTypeTag, 0),
+		Arguments:     make([]*EntryFunctionArg, 0),
+	}
+
+	b.decode(r.Function)
+
+	typeArgCount := b.uleb128()
+	for i := 0; i < typeArgCount && b.err == nil; i++ {
+		r.TypeArguments = append(r.TypeArguments, decodeMoveTypeTag(b))
+	}
+
+	argCount := b.uleb128()
+	for i := 0; i < argCount && b.err == nil; i++ {
+		raw := b.bytes()
+		r.Arguments = append(r.Arguments, &EntryFunctionArg{Raw: &raw})
+	}
+
+	return r
+}
+
 // NewEntryFunctionPayload
 func NewEntryFunctionPayload(
 	functionName *MoveFunctionTag,
@@ -157,12 +269,13 @@ func EntryFunctionArg_Uint128(lo uint64, hi uint64) *EntryFunctionArg {
 	return r
 }
 
+// EntryFunctionArg_String is move's 0x1::string::String.
 func EntryFunctionArg_String(v string) *EntryFunctionArg {
 	r := &EntryFunctionArg{
-		Vector: new([]byte),
+		String: new(string),
 	}
 
-	*r.Vector = append([]byte{}, []byte(v)...)
+	*r.String = v
 
 	return r
 }
@@ -188,6 +301,145 @@ func EntryFunctionArg_Address(v Address) *EntryFunctionArg {
 	return r
 }
 
+// EntryFunctionArg_Uint16 is equivalent to uint16, or u16 in move.
+func EntryFunctionArg_Uint16(v uint16) *EntryFunctionArg {
+	return &EntryFunctionArg{Uint16: &v}
+}
+
+// EntryFunctionArg_Uint32 is equivalent to uint32, or u32 in move.
+func EntryFunctionArg_Uint32(v uint32) *EntryFunctionArg {
+	return &EntryFunctionArg{Uint32: &v}
+}
+
+// EntryFunctionArg_Uint256 is u256 in move.
+func EntryFunctionArg_Uint256(v *Uint256) *EntryFunctionArg {
+	r := *v
+	return &EntryFunctionArg{Uint256: &r}
+}
+
+// EntryFunctionArg_U8Vector is vector<u8> in move.
+func EntryFunctionArg_U8Vector(v []byte) *EntryFunctionArg {
+	r := append([]byte{}, v...)
+	return &EntryFunctionArg{Vector: &r}
+}
+
+// EntryFunctionArg_Vector is a vector of the elements, which must be of the same type. Use [EntryFunctionArg_U8Vector] for vector<u8>.
+func EntryFunctionArg_Vector(elements ...*EntryFunctionArg) *EntryFunctionArg {
+	r := append([]*EntryFunctionArg{}, elements...)
+	return &EntryFunctionArg{Elements: &r}
+}
+
+// EntryFunctionArg_Some is an 0x1::option::Option with the value.
+func EntryFunctionArg_Some(v *EntryFunctionArg) *EntryFunctionArg {
+	return &EntryFunctionArg{Option: &EntryFunctionArgOption{Value: v}}
+}
+
+// EntryFunctionArg_None is an empty 0x1::option::Option.
+func EntryFunctionArg_None() *EntryFunctionArg {
+	return &EntryFunctionArg{Option: &EntryFunctionArgOption{}}
+}
+
+// EntryFunctionArg_VectorOf is a vector of the values converted by f, for example
+//
+//	EntryFunctionArg_VectorOf(addresses, EntryFunctionArg_Address)
+func EntryFunctionArg_VectorOf[T any](values []T, f func(T) *EntryFunctionArg) *EntryFunctionArg {
+	elements := make([]*EntryFunctionArg, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, f(v))
+	}
+
+	return &EntryFunctionArg{Elements: &elements}
+}
+
+// EntryFunctionArg_OptionOf is an 0x1::option::Option of the value converted by f, or none if v is nil.
+func EntryFunctionArg_OptionOf[T any](v *T, f func(T) *EntryFunctionArg) *EntryFunctionArg {
+	if v == nil {
+		return EntryFunctionArg_None()
+	}
+
+	return EntryFunctionArg_Some(f(*v))
+}
+
+// NewEntryFunctionArg creates an [EntryFunctionArg] from a go value:
+//   - bool is bool.
+//   - uint8, uint16, uint32, uint64 are u8, u16, u32, u64. uint and [JsonUint64] are u64.
+//   - [bcs.Uint128] is u128, and [Uint256] is u256.
+//   - [Address] is address.
+//   - string is 0x1::string::String.
+//   - []byte is vector<u8>, and other slices or arrays are vectors of the converted elements.
+//   - [EntryFunctionArg] is returned as is, which can be used for elements of vectors, for example options.
+func NewEntryFunctionArg(v any) (*EntryFunctionArg, error) {
+	switch v := v.(type) {
+	case *EntryFunctionArg:
+		if v == nil {
+			return nil, fmt.Errorf("nil argument")
+		}
+		return v, nil
+	case EntryFunctionArg:
+		return &v, nil
+	case bool:
+		return EntryFunctionArg_Bool(v), nil
+	case uint8:
+		return EntryFunctionArg_Uint8(v), nil
+	case uint16:
+		return EntryFunctionArg_Uint16(v), nil
+	case uint32:
+		return EntryFunctionArg_Uint32(v), nil
+	case uint64:
+		return EntryFunctionArg_Uint64(v), nil
+	case uint:
+		return EntryFunctionArg_Uint64(uint64(v)), nil
+	case JsonUint64:
+		return EntryFunctionArg_Uint64(uint64(v)), nil
+	case bcs.Uint128:
+		return &EntryFunctionArg{Uint128: &v}, nil
+	case *bcs.Uint128:
+		if v == nil {
+			return nil, fmt.Errorf("nil u128")
+		}
+		return NewEntryFunctionArg(*v)
+	case Uint256:
+		return EntryFunctionArg_Uint256(&v), nil
+	case *Uint256:
+		if v == nil {
+			return nil, fmt.Errorf("nil u256")
+		}
+		return EntryFunctionArg_Uint256(v), nil
+	case Address:
+		return EntryFunctionArg_Address(v), nil
+	case *Address:
+		if v == nil {
+			return nil, fmt.Errorf("nil address")
+		}
+		return EntryFunctionArg_Address(*v), nil
+	case string:
+		return EntryFunctionArg_String(v), nil
+	case []byte:
+		return EntryFunctionArg_U8Vector(v), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+
+	elements := make([]*EntryFunctionArg, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		element, err := NewEntryFunctionArg(rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		elements = append(elements, element)
+	}
+
+	return &EntryFunctionArg{Elements: &elements}, nil
+}
+
+// MustNewEntryFunctionArg calls [NewEntryFunctionArg] and panics if there is an error.
+func MustNewEntryFunctionArg(v any) *EntryFunctionArg {
+	return must(NewEntryFunctionArg(v))
+}
+
 // UnmarshalJSON for [EntryFunctionArg]. json doesn't have type information, so the process uses a series of heuristics.
 //
 //   - deserializing response from rest api either in json or bcs is difficult without knowing the types of the elements before
@@ -195,7 +447,7 @@ func EntryFunctionArg_Address(v Address) *EntryFunctionArg {
 //
 //     The following logic is used to deserialize the slices: first, the element of the slice will be first tested if it is an u64 or bool.
 //     Then, it is checked to see if it is a string. If it is a string and it has 0x prefix, cast it to address. If casting to address is unsuccessful,
-//     keep it as a string.
+//     keep it as a string. Arrays are parsed to vectors of the elements, and {"vec": []} is parsed to option.
 //
 //   - during serialization, the element of entry function argument slice is prefixed with the length of the
 //     serialized bytes. For example, instead of serialize true to 01, serialize it to 0101.
@@ -226,8 +478,24 @@ func (s *EntryFunctionArg) UnmarshalJSON(data []byte) error {
 			}
 		}
 		s.reset()
-		s.Vector = new([]byte)
-		*s.Vector = append(*s.Vector, []byte(str)...)
+		s.String = &str
+		return nil
+	}
+	var elements []*EntryFunctionArg
+	if err := json.Unmarshal(data, &elements); err == nil && elements != nil {
+		s.reset()
+		s.Elements = &elements
+		return nil
+	}
+	var option struct {
+		Vec []*EntryFunctionArg `json:"vec"`
+	}
+	if err := json.Unmarshal(data, &option); err == nil && option.Vec != nil && len(option.Vec) <= 1 {
+		s.reset()
+		s.Option = &EntryFunctionArgOption{}
+		if len(option.Vec) == 1 {
+			s.Option.Value = option.Vec[0]
+		}
 		return nil
 	}
 
@@ -0,0 +1,602 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// EntryFunctionArgError is returned when an argument cannot be converted to the type of the parameter.
+type EntryFunctionArgError struct {
+	// Index of the argument, signer parameters are not counted.
+	Index int
+	// Type of the parameter.
+	Type string
+	Err  error
+}
+
+var _ error = (*EntryFunctionArgError)(nil)
+
+func (e *EntryFunctionArgError) Error() string {
+	return fmt.Sprintf("argument %d (%s): %v", e.Index, e.Type, e.Err)
+}
+
+func (e *EntryFunctionArgError) Unwrap() error {
+	return e.Err
+}
+
+// isSignerParam checks if the parameter is a signer, which is not passed as an argument.
+func isSignerParam(param string) bool {
+	return param == "signer" || param == "&signer"
+}
+
+// ParamTypes parses the types of the parameters that are passed as arguments (signers are skipped),
+// with the generic type parameters replaced by the type arguments.
+func (f *MoveModuleABI_Function) ParamTypes(typeArguments []*MoveTypeTag) ([]*MoveTypeTag, error) {
+	if len(typeArguments) != len(f.GenericTypeParams) {
+		return nil, fmt.Errorf("function %s requires %d type arguments, got %d", f.Name, len(f.GenericTypeParams), len(typeArguments))
+	}
+
+	var r []*MoveTypeTag
+	for _, param := range f.Params {
+		if isSignerParam(param) {
+			continue
+		}
+		paramType, err := ParseMoveTypeTagWithTypeParams(param)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse type %s of parameter %d: %w", param, len(r), err)
+		}
+		instantiated, err := paramType.InstantiateTypeParams(typeArguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate type %s of parameter %d: %w", param, len(r), err)
+		}
+		r = append(r, instantiated)
+	}
+
+	return r, nil
+}
+
+// EncodeArguments converts the arguments to [EntryFunctionArg] according to the types of the parameters, see [NewEntryFunctionArgWithType].
+// The number of type arguments and arguments must match the function.
+// A failed conversion returns [EntryFunctionArgError] with the index of the argument.
+func (f *MoveModuleABI_Function) EncodeArguments(typeArguments []*MoveTypeTag, args ...any) ([]*EntryFunctionArg, error) {
+	paramTypes, err := f.ParamTypes(typeArguments)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(paramTypes) {
+		return nil, fmt.Errorf("function %s requires %d arguments, got %d", f.Name, len(paramTypes), len(args))
+	}
+
+	r := make([]*EntryFunctionArg, 0, len(args))
+	for i, arg := range args {
+		encoded, err := NewEntryFunctionArgWithType(paramTypes[i], arg)
+		if err != nil {
+			return nil, &EntryFunctionArgError{Index: i, Type: paramTypes[i].String(), Err: err}
+		}
+		r = append(r, encoded)
+	}
+
+	return r, nil
+}
+
+// NewEntryFunctionArgWithType converts a loosely typed value to [EntryFunctionArg] of the move type:
+//   - bool accepts bool or "true"/"false".
+//   - integers accept go integers, integral floats, [json.Number], decimal or 0x prefixed hex strings, [big.Int], [bcs.Uint128], and [Uint256].
+//     The value must fit in the type.
+//   - address accepts [Address] or string.
+//   - vector<u8> accepts []byte, 0x prefixed hex string, or a vector of u8.
+//   - other vectors accept slices, arrays, or json arrays in strings.
+//   - 0x1::string::String accepts string or []byte.
+//   - 0x1::option::Option accepts nil for none, {"vec": [...]}, or the value.
+//   - 0x1::object::Object accepts the address of the object.
+//
+// [json.RawMessage] is decoded first, and [EntryFunctionArg] is returned as is after its kind is checked against the type.
+func NewEntryFunctionArgWithType(typeTag *MoveTypeTag, v any) (*EntryFunctionArg, error) {
+	switch value := v.(type) {
+	case *EntryFunctionArg:
+		if value == nil {
+			return nil, fmt.Errorf("nil argument")
+		}
+		if err := checkEntryFunctionArgType(typeTag, value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case json.RawMessage:
+		decoded, err := decodeLooseJSON(value)
+		if err != nil {
+			return nil, err
+		}
+		return NewEntryFunctionArgWithType(typeTag, decoded)
+	}
+
+	if typeTag.Struct != nil && isOptionStruct(typeTag.Struct) {
+		return newOptionArgWithType(typeTag.Struct, v)
+	}
+
+	if v == nil {
+		return nil, fmt.Errorf("nil value for %s", typeTag)
+	}
+
+	switch {
+	case typeTag.Bool != nil:
+		b, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Bool(b), nil
+	case typeTag.Uint8 != nil:
+		n, err := toBoundedInteger(v, 8)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Uint8(uint8(n.Uint64())), nil
+	case typeTag.Uint16 != nil:
+		n, err := toBoundedInteger(v, 16)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Uint16(uint16(n.Uint64())), nil
+	case typeTag.Uint32 != nil:
+		n, err := toBoundedInteger(v, 32)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Uint32(uint32(n.Uint64())), nil
+	case typeTag.Uint64 != nil:
+		n, err := toBoundedInteger(v, 64)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Uint64(n.Uint64()), nil
+	case typeTag.Uint128 != nil:
+		n, err := toBoundedInteger(v, 128)
+		if err != nil {
+			return nil, err
+		}
+		return &EntryFunctionArg{Uint128: must(bcs.NewUint128FromBigInt(n))}, nil
+	case typeTag.Uint256 != nil:
+		n, err := toBoundedInteger(v, 256)
+		if err != nil {
+			return nil, err
+		}
+		return &EntryFunctionArg{Uint256: must(NewUint256FromBigInt(n))}, nil
+	case typeTag.Address != nil:
+		address, err := toAddress(v)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Address(address), nil
+	case typeTag.Signer != nil:
+		return nil, fmt.Errorf("signer cannot be passed as an argument")
+	case typeTag.Vector != nil:
+		return newVectorArgWithType(typeTag.Vector, v)
+	case typeTag.Struct != nil:
+		return newStructArgWithType(typeTag.Struct, v)
+	case typeTag.TypeParam != nil:
+		return nil, fmt.Errorf("type parameter %s is not instantiated", typeTag)
+	default:
+		return nil, fmt.Errorf("unset type tag")
+	}
+}
+
+// decodeLooseJSON decodes json into go values, keeping the numbers as [json.Number] to avoid losing precision.
+func decodeLooseJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var r any
+	if err := decoder.Decode(&r); err != nil {
+		return nil, fmt.Errorf("failed to decode json %s: %w", string(data), err)
+	}
+
+	return r, nil
+}
+
+func isStdStruct(s *MoveStructTag, module, name string) bool {
+	return s.Address == AptosStdAddress && s.Module == module && s.Name == name
+}
+
+func isOptionStruct(s *MoveStructTag) bool {
+	return isStdStruct(s, "option", "Option") && len(s.GenericTypeParameters) == 1
+}
+
+func toBool(v any) (bool, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch v {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("cannot convert %v (%T) to bool", v, v)
+}
+
+// maxExactFloat is the max integer float64 can hold without losing precision.
+const maxExactFloat = 1 << 53
+
+func toBigInt(v any) (*big.Int, error) {
+	switch v := v.(type) {
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int8:
+		return big.NewInt(int64(v)), nil
+	case int16:
+		return big.NewInt(int64(v)), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case JsonUint64:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > maxExactFloat {
+			return nil, fmt.Errorf("float %v is not an exact integer", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case json.Number:
+		return toBigInt(string(v))
+	case string:
+		var r *big.Int
+		var ok bool
+		if strings.HasPrefix(v, "0x") {
+			r, ok = new(big.Int).SetString(v[2:], 16)
+		} else {
+			r, ok = new(big.Int).SetString(v, 10)
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot parse %q as an integer", v)
+		}
+		return r, nil
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case bcs.Uint128:
+		return v.Big(), nil
+	case *bcs.Uint128:
+		if v == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		return v.Big(), nil
+	case Uint256:
+		return v.Big(), nil
+	case *Uint256:
+		if v == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		return v.Big(), nil
+	default:
+		return nil, fmt.Errorf("cannot convert %v (%T) to integer", v, v)
+	}
+}
+
+// toBoundedInteger converts the value to an integer that fits in the bits.
+func toBoundedInteger(v any, bits int) (*big.Int, error) {
+	r, err := toBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+	if r.Sign() < 0 {
+		return nil, fmt.Errorf("%s is negative", r)
+	}
+	if r.BitLen() > bits {
+		return nil, fmt.Errorf("%s overflows u%d", r, bits)
+	}
+
+	return r, nil
+}
+
+func toAddress(v any) (Address, error) {
+	switch v := v.(type) {
+	case Address:
+		return v, nil
+	case *Address:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		return ParseAddress(v)
+	}
+
+	return Address{}, fmt.Errorf("cannot convert %v (%T) to address", v, v)
+}
+
+// toElements converts slices, arrays, or json arrays in strings to elements of a vector.
+func toElements(v any) ([]any, error) {
+	if str, ok := v.(string); ok && strings.HasPrefix(strings.TrimSpace(str), "[") {
+		decoded, err := decodeLooseJSON([]byte(str))
+		if err != nil {
+			return nil, err
+		}
+		v = decoded
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot convert %v (%T) to vector", v, v)
+	}
+
+	r := make([]any, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		r = append(r, rv.Index(i).Interface())
+	}
+
+	return r, nil
+}
+
+func newVectorArgWithType(elementType *MoveTypeTag, v any) (*EntryFunctionArg, error) {
+	if elementType.Uint8 != nil {
+		switch v := v.(type) {
+		case []byte:
+			return EntryFunctionArg_U8Vector(v), nil
+		case string:
+			if strings.HasPrefix(v, "0x") {
+				b, err := parseHexString(v)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse %q as hex: %w", v, err)
+				}
+				return EntryFunctionArg_U8Vector(b), nil
+			}
+			if !strings.HasPrefix(strings.TrimSpace(v), "[") {
+				return nil, fmt.Errorf("vector<u8> string must be 0x prefixed hex: %q", v)
+			}
+		}
+	}
+
+	values, err := toElements(v)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*EntryFunctionArg, 0, len(values))
+	for i, value := range values {
+		element, err := NewEntryFunctionArgWithType(elementType, value)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		elements = append(elements, element)
+	}
+
+	if elementType.Uint8 != nil {
+		b := make([]byte, 0, len(elements))
+		for i, element := range elements {
+			if element.Uint8 == nil {
+				return nil, fmt.Errorf("element %d: argument is not u8", i)
+			}
+			b = append(b, *element.Uint8)
+		}
+		return EntryFunctionArg_U8Vector(b), nil
+	}
+
+	return EntryFunctionArg_Vector(elements...), nil
+}
+
+func newOptionArgWithType(optionType *MoveStructTag, v any) (*EntryFunctionArg, error) {
+	if v == nil {
+		return EntryFunctionArg_None(), nil
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return EntryFunctionArg_None(), nil
+		}
+		v = rv.Elem().Interface()
+	}
+
+	if str, ok := v.(string); ok && strings.HasPrefix(strings.TrimSpace(str), "{") {
+		if decoded, err := decodeLooseJSON([]byte(str)); err == nil {
+			v = decoded
+		}
+	}
+
+	valueType := optionType.GenericTypeParameters[0]
+
+	if m, ok := v.(map[string]any); ok {
+		vec, ok := m["vec"]
+		if !ok || len(m) != 1 {
+			return nil, fmt.Errorf("option must be in the format of {\"vec\": []}: %v", m)
+		}
+		values, err := toElements(vec)
+		if err != nil {
+			return nil, err
+		}
+		switch len(values) {
+		case 0:
+			return EntryFunctionArg_None(), nil
+		case 1:
+			v = values[0]
+		default:
+			return nil, fmt.Errorf("option can have at most 1 element, got %d", len(values))
+		}
+	}
+
+	value, err := NewEntryFunctionArgWithType(valueType, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return EntryFunctionArg_Some(value), nil
+}
+
+func newStructArgWithType(structType *MoveStructTag, v any) (*EntryFunctionArg, error) {
+	switch {
+	case isStdStruct(structType, "string", "String"):
+		switch v := v.(type) {
+		case string:
+			return EntryFunctionArg_String(v), nil
+		case []byte:
+			return EntryFunctionArg_String(string(v)), nil
+		}
+		return nil, fmt.Errorf("cannot convert %v (%T) to string", v, v)
+	case isStdStruct(structType, "object", "Object"):
+		address, err := toAddress(v)
+		if err != nil {
+			return nil, err
+		}
+		return EntryFunctionArg_Address(address), nil
+	default:
+		return nil, fmt.Errorf("struct %s cannot be passed as an argument", structType)
+	}
+}
+
+// checkEntryFunctionArgType checks the kind of the argument, and the elements or value recursively, matches the type.
+// [EntryFunctionArg.Raw] is rejected since its type is unknown.
+func checkEntryFunctionArgType(typeTag *MoveTypeTag, arg *EntryFunctionArg) error {
+	if arg == nil {
+		return fmt.Errorf("nil argument for %s", typeTag)
+	}
+
+	var ok bool
+	switch {
+	case typeTag.Bool != nil:
+		ok = arg.Bool != nil
+	case typeTag.Uint8 != nil:
+		ok = arg.Uint8 != nil
+	case typeTag.Uint16 != nil:
+		ok = arg.Uint16 != nil
+	case typeTag.Uint32 != nil:
+		ok = arg.Uint32 != nil
+	case typeTag.Uint64 != nil:
+		ok = arg.Uint64 != nil
+	case typeTag.Uint128 != nil:
+		ok = arg.Uint128 != nil
+	case typeTag.Uint256 != nil:
+		ok = arg.Uint256 != nil
+	case typeTag.Address != nil:
+		ok = arg.Address != nil
+	case typeTag.Signer != nil:
+		ok = arg.Signer != nil
+	case typeTag.Vector != nil:
+		if arg.Vector != nil {
+			ok = typeTag.Vector.Uint8 != nil
+			break
+		}
+		if arg.Elements == nil {
+			break
+		}
+		for i, element := range *arg.Elements {
+			if err := checkEntryFunctionArgType(typeTag.Vector, element); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		ok = true
+	case typeTag.Struct != nil && isOptionStruct(typeTag.Struct):
+		if arg.Option == nil {
+			break
+		}
+		if arg.Option.Value != nil {
+			return checkEntryFunctionArgType(typeTag.Struct.GenericTypeParameters[0], arg.Option.Value)
+		}
+		ok = true
+	case typeTag.Struct != nil && isStdStruct(typeTag.Struct, "string", "String"):
+		ok = arg.String != nil
+	case typeTag.Struct != nil && isStdStruct(typeTag.Struct, "object", "Object"):
+		ok = arg.Address != nil
+	}
+
+	if !ok {
+		return fmt.Errorf("argument doesn't match type %s", typeTag)
+	}
+
+	return nil
+}
+
+// EntryFunctionBuilder creates entry function payloads and view requests from loosely typed arguments,
+// which are converted according to the abi of the function (see [MoveModuleABI_Function.EncodeArguments]).
+// The abis are loaded from the node and cached.
+type EntryFunctionBuilder struct {
+	ABIs *ModuleABICache
+}
+
+// NewEntryFunctionBuilder creates a new [EntryFunctionBuilder] loading the abis with the client.
+func NewEntryFunctionBuilder(client *Client) *EntryFunctionBuilder {
+	return &EntryFunctionBuilder{ABIs: NewModuleABICache(client)}
+}
+
+// NewPayload creates an entry function payload. The function must be an entry function.
+func (b *EntryFunctionBuilder) NewPayload(ctx context.Context, function *MoveFunctionTag, typeArguments []*MoveTypeTag, args ...any) (*TransactionPayload, error) {
+	abi, err := b.ABIs.GetFunction(ctx, function)
+	if err != nil {
+		return nil, err
+	}
+	if !abi.IsEntry {
+		return nil, fmt.Errorf("function %s is not an entry function", function)
+	}
+
+	arguments, err := abi.EncodeArguments(typeArguments, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments for %s: %w", function, err)
+	}
+
+	return &TransactionPayload{
+		EntryFunctionPayload: &EntryFunctionPayload{
+			Function:      function,
+			TypeArguments: append(make([]*MoveTypeTag, 0, len(typeArguments)), typeArguments...),
+			Arguments:     arguments,
+		},
+	}, nil
+}
+
+// NewTransaction creates a transaction calling the entry function, see [EntryFunctionBuilder.NewPayload].
+func (b *EntryFunctionBuilder) NewTransaction(ctx context.Context, sender Address, function *MoveFunctionTag, typeArguments []*MoveTypeTag, args []any, options ...TransactionOption) (*Transaction, error) {
+	payload, err := b.NewPayload(ctx, function, typeArguments, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{Payload: payload}
+
+	ApplyTransactionOptions(tx, options...)
+
+	tx.Sender = sender
+
+	return tx, nil
+}
+
+// NewViewRequest creates a request for [Client.View] or [Client.ViewBcs].
+func (b *EntryFunctionBuilder) NewViewRequest(ctx context.Context, function *MoveFunctionTag, typeArguments []*MoveTypeTag, args ...any) (*ViewRequest, error) {
+	abi, err := b.ABIs.GetFunction(ctx, function)
+	if err != nil {
+		return nil, err
+	}
+	if !abi.IsView {
+		return nil, fmt.Errorf("function %s is not a view function", function)
+	}
+
+	arguments, err := abi.EncodeArguments(typeArguments, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode arguments for %s: %w", function, err)
+	}
+
+	return &ViewRequest{
+		Function:      function,
+		TypeArguments: append(make([]*MoveTypeTag, 0, len(typeArguments)), typeArguments...),
+		Arguments:     arguments,
+	}, nil
+}
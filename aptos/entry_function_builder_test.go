@@ -0,0 +1,185 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+const testModuleABI = `{
+  "address": "0xcafe",
+  "name": "vault",
+  "friends": [],
+  "exposed_functions": [
+    {
+      "name": "deposit",
+      "visibility": "public",
+      "is_entry": true,
+      "generic_type_params": [{"constraints": []}],
+      "params": ["&signer", "address", "u64", "vector<T0>"],
+      "return": []
+    },
+    {
+      "name": "configure",
+      "visibility": "public",
+      "is_entry": true,
+      "generic_type_params": [],
+      "params": ["&signer", "vector<address>", "vector<u8>", "0x1::option::Option<u128>", "0x1::string::String", "vector<vector<u16>>", "u256"],
+      "return": []
+    },
+    {
+      "name": "balance",
+      "visibility": "public",
+      "is_entry": false,
+      "is_view": true,
+      "generic_type_params": [],
+      "params": ["address"],
+      "return": ["u64"]
+    }
+  ],
+  "structs": []
+}`
+
+func newTestEntryFunctionBuilder(t *testing.T) (*aptos.EntryFunctionBuilder, *int) {
+	requestCount := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requestCount += 1
+		if r.URL.Path != "/accounts/0xcafe/module/vault" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"bytecode": "0x00", "abi": %s}`, testModuleABI)
+	}))
+	t.Cleanup(server.Close)
+
+	return aptos.NewEntryFunctionBuilder(aptos.MustNewClient(aptos.Localnet, server.URL)), requestCount
+}
+
+func TestEntryFunctionBuilder_NewPayload(t *testing.T) {
+	builder, requestCount := newTestEntryFunctionBuilder(t)
+	ctx := context.Background()
+	module := aptos.MustParseAddress("0xcafe")
+
+	deposit := aptos.MustNewMoveFunctionTag(module, "vault", "deposit")
+	typeArgs := []*aptos.MoveTypeTag{must(aptos.ParseMoveTypeTag("u8"))}
+	payload, err := builder.NewPayload(ctx, deposit, typeArgs, "0x2", json.Number("100"), "0x0102")
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+
+	want := [][]byte{
+		bcs.MustMarshal(aptos.EntryFunctionArg_Address(aptos.MustParseAddress("0x2"))),
+		bcs.MustMarshal(aptos.EntryFunctionArg_Uint64(100)),
+		bcs.MustMarshal(aptos.EntryFunctionArg_U8Vector([]byte{1, 2})),
+	}
+	for i, arg := range payload.EntryFunctionPayload.Arguments {
+		if got := bcs.MustMarshal(arg); !bytes.Equal(got, want[i]) {
+			t.Errorf("argument %d: want %x, got %x", i, want[i], got)
+		}
+	}
+
+	configure := aptos.MustNewMoveFunctionTag(module, "vault", "configure")
+	payload, err = builder.NewPayload(ctx, configure, nil,
+		json.RawMessage(`["0x1", "0x2"]`),
+		[]any{1.0, json.Number("255")},
+		big.NewInt(7),
+		"name",
+		`[[1, 2], []]`,
+		"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+	)
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+
+	wantArgs := []*aptos.EntryFunctionArg{
+		aptos.MustNewEntryFunctionArg([]aptos.Address{aptos.AptosStdAddress, aptos.MustParseAddress("0x2")}),
+		aptos.EntryFunctionArg_U8Vector([]byte{1, 255}),
+		aptos.EntryFunctionArg_Some(aptos.EntryFunctionArg_Uint128(7, 0)),
+		aptos.EntryFunctionArg_String("name"),
+		aptos.MustNewEntryFunctionArg([][]uint16{{1, 2}, {}}),
+		aptos.EntryFunctionArg_Uint256(aptos.NewUint256FromUint64(^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0))),
+	}
+	if got, want := bcs.MustMarshal(payload), bcs.MustMarshal(aptos.NewEntryFunctionPayload(configure, nil, wantArgs)); !bytes.Equal(got, want) {
+		t.Fatalf("wrong payload:\nwant: %x\ngot:  %x", want, got)
+	}
+
+	if _, err := builder.NewPayload(ctx, configure, nil, nil, nil, nil, "", nil, "0"); err == nil {
+		t.Fatalf("nil vector should fail")
+	}
+
+	if *requestCount != 1 {
+		t.Fatalf("abi should be loaded once, got %d requests", *requestCount)
+	}
+}
+
+func TestEntryFunctionBuilder_errors(t *testing.T) {
+	builder, _ := newTestEntryFunctionBuilder(t)
+	ctx := context.Background()
+	module := aptos.MustParseAddress("0xcafe")
+	deposit := aptos.MustNewMoveFunctionTag(module, "vault", "deposit")
+	typeArgs := []*aptos.MoveTypeTag{must(aptos.ParseMoveTypeTag("u8"))}
+
+	if _, err := builder.NewPayload(ctx, deposit, nil, "0x2", 1, "0x"); err == nil {
+		t.Errorf("missing type argument should fail")
+	}
+	if _, err := builder.NewPayload(ctx, deposit, typeArgs, "0x2", 1); err == nil {
+		t.Errorf("missing argument should fail")
+	}
+	if _, err := builder.NewPayload(ctx, aptos.MustNewMoveFunctionTag(module, "vault", "balance"), nil, "0x2"); err == nil {
+		t.Errorf("non entry function should fail")
+	}
+	if _, err := builder.NewViewRequest(ctx, aptos.MustNewMoveFunctionTag(module, "vault", "balance"), nil, "0x2"); err != nil {
+		t.Errorf("failed to build view request: %v", err)
+	}
+	if _, err := builder.NewViewRequest(ctx, aptos.MustNewMoveFunctionTag(module, "vault", "configure"), nil); err == nil {
+		t.Errorf("non view function should fail")
+	}
+
+	for _, aCase := range []struct {
+		args  []any
+		index int
+	}{
+		{args: []any{"not an address", 1, "0x"}, index: 0},
+		{args: []any{"0x2", -1, "0x"}, index: 1},
+		{args: []any{"0x2", "18446744073709551616", "0x"}, index: 1},
+		{args: []any{"0x2", 1.5, "0x"}, index: 1},
+		{args: []any{"0x2", 1, []int{1, 256}}, index: 2},
+		{args: []any{"0x2", 1, "0102"}, index: 2},
+		{args: []any{aptos.EntryFunctionArg_Uint64(2), 1, "0x"}, index: 0},
+		{args: []any{"0x2", 1, []any{aptos.EntryFunctionArg_Uint64(1)}}, index: 2},
+		{args: []any{"0x2", 1, aptos.EntryFunctionArg_String("0x")}, index: 2},
+	} {
+		_, err := builder.NewPayload(ctx, deposit, typeArgs, aCase.args...)
+		var argErr *aptos.EntryFunctionArgError
+		if !errors.As(err, &argErr) || argErr.Index != aCase.index {
+			t.Errorf("%v: want error for argument %d, got %v", aCase.args, aCase.index, err)
+		}
+	}
+}
+
+func TestNewEntryFunctionArgWithType_passThrough(t *testing.T) {
+	vectorType := must(aptos.ParseMoveTypeTag("vector<0x1::option::Option<u64>>"))
+
+	arg := aptos.EntryFunctionArg_Vector(aptos.EntryFunctionArg_Some(aptos.EntryFunctionArg_Uint64(1)), aptos.EntryFunctionArg_None())
+	if got, err := aptos.NewEntryFunctionArgWithType(vectorType, arg); err != nil || got != arg {
+		t.Fatalf("matching argument should be returned as is: %v", err)
+	}
+
+	wrong := aptos.EntryFunctionArg_Vector(aptos.EntryFunctionArg_Some(aptos.EntryFunctionArg_Uint8(1)))
+	if _, err := aptos.NewEntryFunctionArgWithType(vectorType, wrong); err == nil {
+		t.Fatalf("argument of wrong type should fail")
+	}
+
+	if _, err := aptos.NewEntryFunctionArgWithType(must(aptos.ParseMoveTypeTag("vector<u8>")), []any{aptos.EntryFunctionArg_Uint64(1)}); err == nil {
+		t.Fatalf("u64 element of vector<u8> should fail")
+	}
+}
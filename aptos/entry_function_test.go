@@ -0,0 +1,171 @@
+package aptos_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+var entryFunctionArgTestCases = []struct {
+	name string
+	arg  *aptos.EntryFunctionArg
+	bcs  string
+	json string
+}{
+	{
+		name: "u16",
+		arg:  aptos.EntryFunctionArg_Uint16(0x0102),
+		bcs:  "020201",
+		json: `258`,
+	},
+	{
+		name: "u32",
+		arg:  aptos.EntryFunctionArg_Uint32(1),
+		bcs:  "0401000000",
+		json: `1`,
+	},
+	{
+		name: "u256",
+		arg:  aptos.EntryFunctionArg_Uint256(aptos.NewUint256FromUint64(1, 0, 0, 2)),
+		bcs:  "20" + "0100000000000000" + "0000000000000000" + "0000000000000000" + "0200000000000000",
+		json: `"12554203470773361527671578846415332832204710888928069025793"`,
+	},
+	{
+		name: "string",
+		arg:  aptos.EntryFunctionArg_String("abc"),
+		bcs:  "0403616263",
+		json: `"abc"`,
+	},
+	{
+		name: "vector<u8>",
+		arg:  aptos.EntryFunctionArg_U8Vector([]byte{1, 2}),
+		bcs:  "03020102",
+		json: `"0x0102"`,
+	},
+	{
+		name: "vector<address>",
+		arg:  aptos.MustNewEntryFunctionArg([]aptos.Address{aptos.AptosStdAddress}),
+		bcs:  "2101" + "0000000000000000000000000000000000000000000000000000000000000001",
+		json: `["0x1"]`,
+	},
+	{
+		name: "vector<u64>",
+		arg:  aptos.MustNewEntryFunctionArg([]uint64{1, 2}),
+		bcs:  "1102" + "0100000000000000" + "0200000000000000",
+		json: `["1","2"]`,
+	},
+	{
+		name: "vector<vector<u8>>",
+		arg:  aptos.MustNewEntryFunctionArg([][]byte{{1, 2}, {3}}),
+		bcs:  "06" + "02" + "020102" + "0103",
+		json: `["0x0102","0x03"]`,
+	},
+	{
+		name: "vector<vector<u32>>",
+		arg:  aptos.MustNewEntryFunctionArg([][]uint32{{1}, {}}),
+		bcs:  "07" + "02" + "0101000000" + "00",
+		json: `[[1],[]]`,
+	},
+	{
+		name: "option<u16>",
+		arg:  aptos.EntryFunctionArg_Some(aptos.EntryFunctionArg_Uint16(5)),
+		bcs:  "03" + "01" + "0500",
+		json: `{"vec":[5]}`,
+	},
+	{
+		name: "none",
+		arg:  aptos.EntryFunctionArg_None(),
+		bcs:  "0100",
+		json: `{"vec":[]}`,
+	},
+	{
+		name: "vector<option<string>>",
+		arg:  aptos.EntryFunctionArg_Vector(aptos.EntryFunctionArg_Some(aptos.EntryFunctionArg_String("a")), aptos.EntryFunctionArg_None()),
+		bcs:  "05" + "02" + "010161" + "00",
+		json: `[{"vec":["a"]},{"vec":[]}]`,
+	},
+	{
+		name: "vector<option<string>> of go values",
+		arg: aptos.EntryFunctionArg_VectorOf([]*string{&[]string{"a"}[0], nil}, func(v *string) *aptos.EntryFunctionArg {
+			return aptos.EntryFunctionArg_OptionOf(v, aptos.EntryFunctionArg_String)
+		}),
+		bcs:  "05" + "02" + "010161" + "00",
+		json: `[{"vec":["a"]},{"vec":[]}]`,
+	},
+}
+
+func TestEntryFunctionArg(t *testing.T) {
+	for _, aCase := range entryFunctionArgTestCases {
+		encoded, err := bcs.Marshal(aCase.arg)
+		if err != nil {
+			t.Errorf("%s: failed to marshal bcs: %v", aCase.name, err)
+		} else if hex.EncodeToString(encoded) != aCase.bcs {
+			t.Errorf("%s: wrong bcs\nwant: %s\ngot:  %x", aCase.name, aCase.bcs, encoded)
+		}
+
+		jsonBytes, err := json.Marshal(aCase.arg)
+		if err != nil {
+			t.Errorf("%s: failed to marshal json: %v", aCase.name, err)
+		} else if string(jsonBytes) != aCase.json {
+			t.Errorf("%s: wrong json\nwant: %s\ngot:  %s", aCase.name, aCase.json, jsonBytes)
+		}
+	}
+}
+
+func TestEntryFunctionArg_UnmarshalJSON(t *testing.T) {
+	var args []*aptos.EntryFunctionArg
+	if err := json.Unmarshal([]byte(`["abc", ["1", "0x1"], {"vec": [true]}]`), &args); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if len(args) != 3 || args[0].String == nil || *args[0].String != "abc" {
+		t.Fatalf("string is not parsed: %#v", args)
+	}
+	if args[1].Elements == nil || len(*args[1].Elements) != 2 || *(*args[1].Elements)[0].Uint64 != 1 || *(*args[1].Elements)[1].Address != aptos.AptosStdAddress {
+		t.Fatalf("vector is not parsed: %#v", args[1])
+	}
+	if args[2].Option == nil || args[2].Option.Value == nil || !*args[2].Option.Value.Bool {
+		t.Fatalf("option is not parsed: %#v", args[2])
+	}
+}
+
+func TestNewEntryFunctionArg(t *testing.T) {
+	if _, err := aptos.NewEntryFunctionArg(-1); err == nil {
+		t.Fatalf("signed integer should not be supported")
+	}
+
+	_, err := aptos.NewEntryFunctionArg([][]any{{uint8(1)}, {1.5}})
+	if err == nil || !strings.Contains(err.Error(), "element 1: element 0: unsupported type float64") {
+		t.Fatalf("wrong error for nested unsupported type: %v", err)
+	}
+
+	arg, err := aptos.NewEntryFunctionArg(aptos.EntryFunctionArg_None())
+	if err != nil || arg.Option == nil {
+		t.Fatalf("entry function arg should be returned as is: %#v %v", arg, err)
+	}
+}
+
+func TestUint256(t *testing.T) {
+	maxStr := "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	v, err := aptos.NewUint256(maxStr)
+	if err != nil {
+		t.Fatalf("failed to parse max u256: %v", err)
+	}
+	if v.String() != maxStr || v.Cmp(aptos.NewUint256FromUint64(0, 0, 0, 1<<63)) != 1 {
+		t.Fatalf("wrong value: %s", v)
+	}
+
+	if _, err := aptos.NewUint256(maxStr[:len(maxStr)-1] + "6"); err == nil {
+		t.Fatalf("overflow should fail")
+	}
+
+	encoded := bcs.MustMarshal(aptos.NewUint256FromUint64(1, 2, 3, 4))
+	decoded := &aptos.Uint256{}
+	if _, err := bcs.Unmarshal(encoded, decoded); err != nil || decoded.Cmp(aptos.NewUint256FromUint64(1, 2, 3, 4)) != 0 {
+		t.Fatalf("failed to round trip bcs: %s %v", decoded, err)
+	}
+}
@@ -0,0 +1,155 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// FeePayerTransaction is a transaction whose gas is paid by the fee payer (or sponsor) instead of the sender.
+// The sender, the secondary signers (if any), and the fee payer all sign the transaction.
+type FeePayerTransaction struct {
+	*Transaction
+	SecondarySigners []Address
+	FeePayer         Address
+}
+
+// NewFeePayerTransaction creates a fee payer transaction from the transaction, the fee payer, and the addresses of the secondary signers.
+func NewFeePayerTransaction(tx *Transaction, feePayer Address, secondarySigners ...Address) *FeePayerTransaction {
+	return &FeePayerTransaction{
+		Transaction:      tx,
+		SecondarySigners: secondarySigners,
+		FeePayer:         feePayer,
+	}
+}
+
+// EncodeFeePayerTransaction for signing.
+//
+// The signing message is sha3-256 of "APTOS::RawTransactionWithData", followed by bcs encoded RawTransactionWithData::MultiAgentWithFeePayer:
+//
+//   - 0x01, the variant of MultiAgentWithFeePayer
+//   - bcs encoded raw transaction
+//   - secondary signer addresses
+//   - fee payer address
+//
+// Sender, secondary signers, and the fee payer sign the same message.
+func EncodeFeePayerTransaction(tx *FeePayerTransaction) ([]byte, error) {
+	txBytes, err := bcs.Marshal(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	addressBytes, err := bcs.Marshal(tx.SecondarySigners)
+	if err != nil {
+		return nil, err
+	}
+
+	r := append([]byte{}, rawTransactionWithDataPrefix...)
+	r = append(r, rawTransactionWithDataVariant_FeePayer)
+	r = append(r, txBytes...)
+	r = append(r, addressBytes...)
+	r = append(r, tx.FeePayer[:]...)
+
+	return r, nil
+}
+
+// FeePayerAuthenticator contains the authenticators of the sender, the secondary signers, and the fee payer of a [FeePayerTransaction].
+type FeePayerAuthenticator struct {
+	Sender                   *AccountAuthenticator
+	SecondarySignerAddresses []Address
+	SecondarySigners         []*AccountAuthenticator
+	FeePayerAddress          Address
+	FeePayerSigner           *AccountAuthenticator
+}
+
+func (a *FeePayerAuthenticator) decode(b *bcsReader) {
+	multiAgent := &MultiAgentAuthenticator{}
+	multiAgent.decode(b)
+	a.Sender = multiAgent.Sender
+	a.SecondarySignerAddresses = multiAgent.SecondarySignerAddresses
+	a.SecondarySigners = multiAgent.SecondarySigners
+
+	b.decode(&a.FeePayerAddress)
+
+	a.FeePayerSigner = &AccountAuthenticator{}
+	a.FeePayerSigner.decode(b)
+}
+
+// signFeePayerTransaction collects the signatures of the sender and secondary signers, and the fee payer if it is not nil.
+// If the fee payer is nil, [AccountAuthenticator].NoAccountAuthenticator is used, which is only valid for simulation.
+func signFeePayerTransaction(
+	tx *FeePayerTransaction,
+	sign rawDataSignFunc,
+	sender MultiAgentSigner,
+	secondarySigners []MultiAgentSigner,
+	feePayer MultiAgentSigner,
+) (*SignedTransaction, error) {
+	message, err := EncodeFeePayerTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	senderAuthenticator, secondaryAuthenticators, err := signRawTransactionWithData(tx.Transaction, tx.SecondarySigners, message, sign, sender, secondarySigners)
+	if err != nil {
+		return nil, err
+	}
+
+	feePayerAuthenticator := &AccountAuthenticator{NoAccountAuthenticator: &struct{}{}}
+	if feePayer != nil {
+		if feePayer.SignerAddress() != tx.FeePayer {
+			return nil, fmt.Errorf("signer %s is not the fee payer %s", feePayer.SignerAddress(), tx.FeePayer)
+		}
+		feePayerAuthenticator, err = signForAccountAuthenticator(message, sign, feePayer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign for fee payer: %w", err)
+		}
+	}
+
+	return &SignedTransaction{
+		Transaction: tx.Transaction,
+		Authenticator: &TransactionAuthenticator{
+			FeePayer: &FeePayerAuthenticator{
+				Sender:                   senderAuthenticator,
+				SecondarySignerAddresses: tx.SecondarySigners,
+				SecondarySigners:         secondaryAuthenticators,
+				FeePayerAddress:          tx.FeePayer,
+				FeePayerSigner:           feePayerAuthenticator,
+			},
+		},
+	}, nil
+}
+
+// SignFeePayerTransaction signs the fee payer transaction by the sender, the fee payer, and the secondary signers.
+// The secondary signers must be in the same order as [FeePayerTransaction].SecondarySigners, and the addresses of the signers are checked.
+func SignFeePayerTransaction(tx *FeePayerTransaction, sender MultiAgentSigner, feePayer MultiAgentSigner, secondarySigners ...MultiAgentSigner) (*SignedTransaction, error) {
+	if feePayer == nil {
+		return nil, fmt.Errorf("fee payer is required")
+	}
+
+	return signFeePayerTransaction(tx, RawDataSigner.SignRawData, sender, secondarySigners, feePayer)
+}
+
+// SignFeePayerTransactionForSimulation creates the [SignedTransaction] for [Client.SimulateSignedTransactionBCS].
+// The sender and secondary signers sign with invalid signatures, and the fee payer's signature is left empty.
+func SignFeePayerTransactionForSimulation(tx *FeePayerTransaction, sender MultiAgentSigner, secondarySigners ...MultiAgentSigner) (*SignedTransaction, error) {
+	return signFeePayerTransaction(tx, RawDataSigner.SignRawDataForSimulation, sender, secondarySigners, nil)
+}
+
+// SignSubmitSponsoredTransactionWait is the sponsored version of [Client.SignSubmitTransactionWait]:
+// the transaction is signed by the signer as the sender and the sponsor as the fee payer, and the gas is paid by the sponsor.
+// The transaction is submitted in bcs.
+func (client *Client) SignSubmitSponsoredTransactionWait(
+	ctx context.Context,
+	signer MultiAgentSigner,
+	sponsor MultiAgentSigner,
+	tx *Transaction,
+	noWait bool,
+	waitOptions ...TransactionWaitOption,
+) (*TransactionWithInfo, error) {
+	signedTx, err := SignFeePayerTransaction(NewFeePayerTransaction(tx, sponsor.SignerAddress()), signer, sponsor)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.submitSignedTransactionBCSWait(ctx, signedTx, noWait, waitOptions...)
+}
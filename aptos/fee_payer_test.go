@@ -0,0 +1,108 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func TestSignFeePayerTransaction(t *testing.T) {
+	multiAgentTx, sender, _ := testMultiAgentTransaction(t)
+	sponsor, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := aptos.NewFeePayerTransaction(multiAgentTx.Transaction, sponsor.Address)
+
+	signedTx, err := aptos.SignFeePayerTransaction(tx, sender, sponsor)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	message, err := aptos.EncodeFeePayerTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasSuffix(message, sponsor.Address[:]) {
+		t.Fatalf("fee payer address is not in the signing message")
+	}
+
+	auth := signedTx.Authenticator.FeePayer
+	if auth == nil || auth.FeePayerAddress != sponsor.Address {
+		t.Fatalf("wrong authenticator: %#v", signedTx.Authenticator)
+	}
+	for i, a := range []*aptos.AccountAuthenticator{auth.Sender, auth.FeePayerSigner} {
+		if !ed25519.Verify(a.Ed25519.PublicKey, message, a.Ed25519.Signature) {
+			t.Errorf("signature %d cannot be verified", i)
+		}
+	}
+
+	encoded := bcs.MustMarshal(signedTx)
+	var decoded aptos.SignedTransaction
+	if _, err := bcs.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if reEncoded := bcs.MustMarshal(&decoded); !bytes.Equal(encoded, reEncoded) {
+		t.Fatalf("round trip changes the encoding:\nwant: %x\ngot:  %x", encoded, reEncoded)
+	}
+
+	if _, err := aptos.SignFeePayerTransaction(tx, sender, sender); err == nil {
+		t.Fatalf("wrong fee payer should fail")
+	}
+}
+
+func TestClient_SignSubmitSponsoredTransactionWait(t *testing.T) {
+	multiAgentTx, sender, _ := testMultiAgentTransaction(t)
+	sponsor, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := multiAgentTx.Transaction
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var signedTx aptos.SignedTransaction
+		if _, err := bcs.Unmarshal(body, &signedTx); err != nil {
+			t.Errorf("failed to decode submitted transaction: %v", err)
+		}
+		if signedTx.Authenticator.FeePayer == nil || signedTx.Authenticator.FeePayer.FeePayerAddress != sponsor.Address {
+			t.Errorf("submitted transaction is not sponsored")
+		}
+		if r.URL.Path == "/transactions/simulate" {
+			if signedTx.Authenticator.FeePayer.FeePayerSigner.NoAccountAuthenticator == nil {
+				t.Errorf("fee payer should not sign for simulation")
+			}
+			w.Write([]byte(`[{"type": "user_transaction", "hash": "0x01", "success": true}]`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"type": "pending_transaction", "hash": "0x01"}`))
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+
+	simulationTx, err := aptos.SignFeePayerTransactionForSimulation(aptos.NewFeePayerTransaction(tx, sponsor.Address), sender)
+	if err != nil {
+		t.Fatalf("failed to sign for simulation: %v", err)
+	}
+	if _, err := client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{SignedTransaction: simulationTx}); err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+
+	txInfo, err := client.SignSubmitSponsoredTransactionWait(context.Background(), sender, sponsor, tx, true)
+	if err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+	if txInfo.Hash != "0x01" {
+		t.Fatalf("wrong hash: %s", txInfo.Hash)
+	}
+}
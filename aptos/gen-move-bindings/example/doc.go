@@ -0,0 +1,4 @@
+// Package example contains the bindings generated by gen-move-bindings for the module in vault.json.
+package example
+
+//go:generate go run ../ -abi vault.json -o vault.go
@@ -0,0 +1,98 @@
+package example_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-aptos/aptos/gen-move-bindings/example"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func TestVault(t *testing.T) {
+	vault := example.NewVault(example.VaultDefaultAddress)
+	sender := aptos.MustParseAddress("0x2")
+	coinType := &aptos.MoveTypeTag{Struct: aptos.MustNewMoveStructTag(aptos.AptosStdAddress, "aptos_coin", "AptosCoin", nil)}
+
+	tx := vault.Deposit(sender, coinType, 100, &sender, aptos.TransactionOption_MaxGasAmount(1000))
+	if tx.Sender != sender || tx.MaxGasAmount != 1000 {
+		t.Fatalf("wrong sender or options: %#v", tx)
+	}
+
+	want := aptos.NewEntryFunctionPayload(
+		aptos.MustNewMoveFunctionTag(example.VaultDefaultAddress, example.VaultModuleName, "deposit"),
+		[]*aptos.MoveStructTag{coinType.Struct},
+		[]*aptos.EntryFunctionArg{aptos.EntryFunctionArg_Uint64(100), aptos.EntryFunctionArg_Some(aptos.EntryFunctionArg_Address(sender))},
+	)
+	if !bytes.Equal(bcs.MustMarshal(tx.Payload), bcs.MustMarshal(want)) {
+		t.Fatalf("wrong payload:\nwant: %x\ngot:  %x", bcs.MustMarshal(want), bcs.MustMarshal(tx.Payload))
+	}
+
+	tx = vault.SetAdmins(sender, []aptos.Address{sender}, [][]byte{{1}})
+	want = aptos.NewEntryFunctionPayload(
+		aptos.MustNewMoveFunctionTag(example.VaultDefaultAddress, example.VaultModuleName, "set_admins"),
+		nil,
+		[]*aptos.EntryFunctionArg{aptos.EntryFunctionArg_Vector(aptos.EntryFunctionArg_Address(sender)), aptos.EntryFunctionArg_Vector(aptos.EntryFunctionArg_U8Vector([]byte{1}))},
+	)
+	if !bytes.Equal(bcs.MustMarshal(tx.Payload), bcs.MustMarshal(want)) {
+		t.Fatalf("wrong payload:\nwant: %x\ngot:  %x", bcs.MustMarshal(want), bcs.MustMarshal(tx.Payload))
+	}
+
+	u64Type := &aptos.MoveTypeTag{Uint64: &struct{}{}}
+	tx, err := vault.SetLimits(sender, u64Type, []any{uint64(1), "2"}, *bcs.NewUint128FromUint64(3, 0))
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	arguments := tx.Payload.EntryFunctionPayload.Arguments
+	wantArguments := []*aptos.EntryFunctionArg{
+		aptos.EntryFunctionArg_Vector(aptos.EntryFunctionArg_Uint64(1), aptos.EntryFunctionArg_Uint64(2)),
+		aptos.EntryFunctionArg_Uint128(3, 0),
+	}
+	if !bytes.Equal(bcs.MustMarshal(arguments), bcs.MustMarshal(wantArguments)) {
+		t.Fatalf("wrong arguments:\nwant: %x\ngot:  %x", bcs.MustMarshal(wantArguments), bcs.MustMarshal(arguments))
+	}
+	if _, err := vault.SetLimits(sender, u64Type, []any{-1}, *bcs.NewUint128FromUint64(3, 0)); err == nil {
+		t.Fatalf("negative u64 is converted")
+	}
+
+	vaultData := &example.VaultVault{}
+	if err := json.Unmarshal([]byte(`{"total_shares": "10", "fee_rate": "5", "description": {"vec": ["test"]}, "last_deposit": {"vec": []}}`), vaultData); err != nil {
+		t.Fatalf("failed to unmarshal vault: %v", err)
+	}
+	if description, ok := vaultData.Description.Get(); !ok || description != "test" || vaultData.TotalShares != 10 {
+		t.Fatalf("wrong vault: %#v", vaultData)
+	}
+	if tag := vault.VaultType(coinType).String(); tag != "0xcafe::vault::Vault<0x1::aptos_coin::AptosCoin>" {
+		t.Fatalf("wrong struct tag: %s", tag)
+	}
+}
+
+func TestVault_ViewSharesOf(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Function  string   `json:"function"`
+			Arguments []string `json:"arguments"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Function != "0xcafe::vault::shares_of" || len(request.Arguments) != 1 {
+			t.Errorf("wrong request: %#v %v", request, err)
+		}
+		w.Write([]byte(`["12", {"vec": ["340282366920938463463374607431768211455"]}]`))
+	}))
+	defer server.Close()
+
+	vault := example.NewVault(example.VaultDefaultAddress)
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	coinType := &aptos.MoveTypeTag{Struct: aptos.MustNewMoveStructTag(aptos.AptosStdAddress, "aptos_coin", "AptosCoin", nil)}
+
+	shares, maxShares, err := vault.ViewSharesOf(context.Background(), client, coinType, aptos.MustParseAddress("0x2"))
+	if err != nil {
+		t.Fatalf("failed to view: %v", err)
+	}
+	if v, ok := maxShares.Get(); shares != 12 || !ok || v.String() != "340282366920938463463374607431768211455" {
+		t.Fatalf("wrong return values: %d %v", shares, maxShares)
+	}
+}
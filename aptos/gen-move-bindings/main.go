@@ -0,0 +1,334 @@
+// gen-move-bindings generates go bindings for a move module from its abi.
+//
+// The abi is loaded from a json file (either the abi, or the module returned by the node, which contains the abi),
+// or from the node by the module id. For each module, the generated code contains
+//   - a go struct for each move struct (including events) of the module, which can be unmarshaled from the json returned by the node,
+//     and a method to create the [aptos.MoveStructTag] of the struct.
+//   - a method to create the [aptos.Transaction] for each entry function.
+//   - a method to call each view function and unmarshal the return values.
+//
+// Add the following line to a go file in the package for go generate
+//
+//	//go:generate go run github.com/fardream/go-aptos/aptos/gen-move-bindings -abi vault.json -package mypackage -o vault.go
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/fardream/go-aptos/aptos"
+	"mvdan.cc/gofumpt/format"
+)
+
+//go:embed move_binding.go.template
+var moveBindingGoTemplate string
+
+type fieldData struct {
+	Name   string
+	GoName string
+	GoType string
+}
+
+type structData struct {
+	Name       string
+	PascalName string
+	GoName     string
+	TypeParams string
+	// TypeParamNames are the names of the type parameters.
+	TypeParamNames []string
+	Abilities      string
+	Fields         []*fieldData
+}
+
+type argData struct {
+	Name string
+	// Expr converts the parameter to *aptos.EntryFunctionArg.
+	Expr string
+	// CanFail is set if Expr returns an error along with the argument.
+	CanFail bool
+}
+
+type functionData struct {
+	Name   string
+	GoName string
+	// Signature is the type parameters and parameters of the go function.
+	Signature string
+	// TypeArgs is the list of type parameters.
+	TypeArgs string
+	Args     []*argData
+	// CanFail is set if the conversion of any of the arguments can fail.
+	CanFail bool
+	Returns []string
+}
+
+type moduleData struct {
+	Package    string
+	Source     string
+	Address    string
+	ModuleName string
+	GoName     string
+	Imports    []string
+
+	Structs        []*structData
+	EntryFunctions []*functionData
+	ViewFunctions  []*functionData
+}
+
+// typeParamNames returns t0, t1, ... for the generic type parameters.
+func typeParamNames(n int) []string {
+	r := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		r = append(r, fmt.Sprintf("t%d", i))
+	}
+
+	return r
+}
+
+// typeParamsSignature returns "t0, t1 *aptos.MoveTypeTag".
+func typeParamsSignature(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strings.Join(typeParamNames(n), ", ") + " *aptos.MoveTypeTag"
+}
+
+func newStructData(m *typeMapper, s *aptos.MoveModuleABI_Struct) (*structData, error) {
+	r := &structData{
+		Name:       s.Name,
+		PascalName: snakeToPascal(s.Name),
+		GoName:     m.structGoName(s.Name),
+		TypeParams: typeParamsSignature(len(s.GenericTypeParams)),
+
+		TypeParamNames: typeParamNames(len(s.GenericTypeParams)),
+	}
+
+	abilities := make([]string, 0, len(s.Abilities))
+	for _, ability := range s.Abilities {
+		abilities = append(abilities, string(ability))
+	}
+	r.Abilities = strings.Join(abilities, ", ")
+
+	for _, field := range s.Fields {
+		r.Fields = append(r.Fields, &fieldData{
+			Name:   field.Name,
+			GoName: snakeToPascal(field.Name),
+			GoType: m.jsonGoType(field.Type),
+		})
+	}
+
+	return r, nil
+}
+
+// newFunctionData returns nil if some parameters of the function cannot be passed as arguments.
+func newFunctionData(m *typeMapper, f *aptos.MoveModuleABI_Function) (*functionData, error) {
+	r := &functionData{
+		Name:     f.Name,
+		GoName:   snakeToPascal(f.Name),
+		TypeArgs: strings.Join(typeParamNames(len(f.GenericTypeParams)), ", "),
+	}
+
+	var signature []string
+	if len(f.GenericTypeParams) > 0 {
+		signature = append(signature, typeParamsSignature(len(f.GenericTypeParams)))
+	}
+	for _, param := range f.Params {
+		if param == "signer" || param == "&signer" {
+			continue
+		}
+		paramType, err := aptos.ParseMoveTypeTagWithTypeParams(param)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse type %s of %s: %w", param, f.Name, err)
+		}
+		goType := m.argGoType(paramType)
+		if goType == "" {
+			fmt.Fprintf(os.Stderr, "skipping function %s: %s cannot be an argument\n", f.Name, param)
+			return nil, nil
+		}
+		name := fmt.Sprintf("arg%d", len(r.Args))
+		expr, canFail := m.argExpr(paramType, name)
+		r.Args = append(r.Args, &argData{Name: name, Expr: expr, CanFail: canFail})
+		r.CanFail = r.CanFail || canFail
+		signature = append(signature, name+" "+goType)
+	}
+	r.Signature = strings.Join(signature, ", ")
+
+	if f.IsView {
+		for _, ret := range f.Return {
+			retType, err := aptos.ParseMoveTypeTagWithTypeParams(ret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse return type %s of %s: %w", ret, f.Name, err)
+			}
+			r.Returns = append(r.Returns, m.jsonGoType(retType))
+		}
+	}
+
+	return r, nil
+}
+
+func newModuleData(abi *aptos.MoveModuleABI, packageName, source, goName string) (*moduleData, error) {
+	if goName == "" {
+		goName = snakeToPascal(abi.Name)
+	}
+
+	m := &typeMapper{module: abi, goName: goName, imports: make(map[string]bool)}
+	m.use("github.com/fardream/go-aptos/aptos")
+
+	r := &moduleData{
+		Package:    packageName,
+		Source:     source,
+		Address:    abi.Address.String(),
+		ModuleName: abi.Name,
+		GoName:     goName,
+	}
+
+	for _, s := range abi.Structs {
+		if s.IsNative {
+			continue
+		}
+		data, err := newStructData(m, s)
+		if err != nil {
+			return nil, err
+		}
+		r.Structs = append(r.Structs, data)
+	}
+
+	for _, f := range abi.Functions {
+		if !f.IsEntry && !f.IsView {
+			continue
+		}
+		data, err := newFunctionData(m, f)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		if f.IsEntry {
+			r.EntryFunctions = append(r.EntryFunctions, data)
+		}
+		if f.IsView {
+			m.use("context")
+			m.use("encoding/json")
+			m.use("fmt")
+			r.ViewFunctions = append(r.ViewFunctions, data)
+		}
+	}
+
+	for pkg := range m.imports {
+		r.Imports = append(r.Imports, pkg)
+	}
+	sort.Strings(r.Imports)
+
+	return r, nil
+}
+
+// loadABIFile loads the abi from the file, which can be the abi or the module containing the abi.
+func loadABIFile(file string) (*aptos.MoveModuleABI, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var module aptos.AccountModule
+	if err := json.Unmarshal(data, &module); err == nil && module.Abi != nil {
+		return module.Abi, nil
+	}
+
+	abi := &aptos.MoveModuleABI{}
+	if err := json.Unmarshal(data, abi); err != nil {
+		return nil, fmt.Errorf("failed to parse abi from %s: %w", file, err)
+	}
+	if abi.Name == "" {
+		return nil, fmt.Errorf("%s doesn't contain a module abi", file)
+	}
+
+	return abi, nil
+}
+
+func loadABIFromNode(network aptos.Network, url, moduleId string) (*aptos.MoveModuleABI, error) {
+	module, err := aptos.ParseMoveModuleTag(moduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := aptos.NewClient(network, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return aptos.NewModuleABICache(client).Get(context.Background(), module)
+}
+
+func orPanic(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	abiFile := flag.String("abi", "", "json file containing the abi of the module")
+	moduleId := flag.String("module", "", "module to load from the node, in the format of address::module")
+	network := aptos.Mainnet
+	flag.Var(&network, "network", "network of the node to load the module from")
+	url := flag.String("url", "", "rest url of the node, default to the url of the network")
+	packageName := flag.String("package", "", "package name of the generated code")
+	goName := flag.String("name", "", "go name of the module, default to the module name in PascalCase")
+	output := flag.String("o", "", "output file, default to stdout")
+	flag.Parse()
+
+	if *packageName == "" {
+		*packageName = os.Getenv("GOPACKAGE")
+	}
+	if *packageName == "" {
+		orPanic(fmt.Errorf("package name is required"))
+	}
+
+	var abi *aptos.MoveModuleABI
+	var source string
+	var err error
+	switch {
+	case *abiFile != "":
+		abi, err = loadABIFile(*abiFile)
+		source = *abiFile
+	case *moduleId != "":
+		abi, err = loadABIFromNode(network, *url, *moduleId)
+		source = *moduleId
+	default:
+		err = fmt.Errorf("either -abi or -module is required")
+	}
+	orPanic(err)
+
+	data, err := newModuleData(abi, *packageName, source, *goName)
+	orPanic(err)
+
+	tmpl, err := template.New("temp").Parse(moveBindingGoTemplate)
+	orPanic(err)
+
+	var buf bytes.Buffer
+	orPanic(tmpl.Execute(&buf, data))
+
+	formatted, err := format.Source(buf.Bytes(), format.Options{
+		LangVersion: "v1.19",
+		ExtraRules:  true,
+	})
+	if err != nil {
+		os.Stderr.Write(buf.Bytes())
+		orPanic(err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(formatted)
+		return
+	}
+
+	orPanic(os.WriteFile(*output, formatted, 0o666))
+}
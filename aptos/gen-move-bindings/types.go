@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func isStdStruct(s *aptos.MoveStructTag, module, name string) bool {
+	return s.Address == aptos.AptosStdAddress && s.Module == module && s.Name == name
+}
+
+// snakeToPascal converts snake_case to PascalCase.
+func snakeToPascal(s string) string {
+	var sb strings.Builder
+	for _, segment := range strings.Split(s, "_") {
+		if segment == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(segment[:1]))
+		sb.WriteString(segment[1:])
+	}
+
+	return sb.String()
+}
+
+// typeMapper maps the move types to go types, and records the packages used.
+type typeMapper struct {
+	module  *aptos.MoveModuleABI
+	goName  string
+	imports map[string]bool
+}
+
+func (m *typeMapper) use(pkg string) {
+	m.imports[pkg] = true
+}
+
+func (m *typeMapper) aptosType(name string) string {
+	m.use("github.com/fardream/go-aptos/aptos")
+	return "aptos." + name
+}
+
+// structGoName is the name of the go type generated for the struct of the module.
+func (m *typeMapper) structGoName(name string) string {
+	return m.goName + name
+}
+
+func (m *typeMapper) isModuleStruct(s *aptos.MoveStructTag) bool {
+	return s.Address == m.module.Address && s.Module == m.module.Name
+}
+
+// jsonGoType is the go type to unmarshal the json representation of the move type from the node,
+// used by the fields of structs and the return values of view functions.
+func (m *typeMapper) jsonGoType(t *aptos.MoveTypeTag) string {
+	switch {
+	case t.Bool != nil:
+		return "bool"
+	case t.Uint8 != nil:
+		return "uint8"
+	case t.Uint16 != nil:
+		return "uint16"
+	case t.Uint32 != nil:
+		return "uint32"
+	case t.Uint64 != nil:
+		return m.aptosType("JsonUint64")
+	case t.Uint128 != nil:
+		m.use("github.com/fardream/go-bcs/bcs")
+		return "bcs.Uint128"
+	case t.Uint256 != nil:
+		return m.aptosType("Uint256")
+	case t.Address != nil:
+		return m.aptosType("Address")
+	case t.Vector != nil && t.Vector.Uint8 != nil:
+		return m.aptosType("MoveBytecode")
+	case t.Vector != nil:
+		return "[]" + m.jsonGoType(t.Vector)
+	case t.Struct != nil:
+		return m.jsonStructGoType(t.Struct)
+	default:
+		m.use("encoding/json")
+		return "json.RawMessage"
+	}
+}
+
+func (m *typeMapper) jsonStructGoType(s *aptos.MoveStructTag) string {
+	params := s.GenericTypeParameters
+	switch {
+	case isStdStruct(s, "string", "String"):
+		return "string"
+	case isStdStruct(s, "option", "Option") && len(params) == 1:
+		return fmt.Sprintf("%s[%s]", m.aptosType("MoveOption"), m.jsonGoType(params[0]))
+	case isStdStruct(s, "simple_map", "SimpleMap") && len(params) == 2:
+		return fmt.Sprintf("%s[%s, %s]", m.aptosType("SimpleMap"), m.jsonGoType(params[0]), m.jsonGoType(params[1]))
+	case isStdStruct(s, "table", "Table"):
+		return m.aptosType("Table")
+	case isStdStruct(s, "table_with_length", "TableWithLength"):
+		return m.aptosType("TableWithLength")
+	case isStdStruct(s, "coin", "Coin"):
+		return m.aptosType("Coin")
+	case isStdStruct(s, "event", "EventHandle"):
+		return m.aptosType("EventHandler")
+	case m.isModuleStruct(s):
+		return "*" + m.structGoName(s.Name)
+	default:
+		m.use("encoding/json")
+		return "json.RawMessage"
+	}
+}
+
+// argGoType is the go type of an argument of the functions, which is converted to [aptos.EntryFunctionArg] by [typeMapper.argExpr].
+// Empty string is returned if the type cannot be an argument.
+func (m *typeMapper) argGoType(t *aptos.MoveTypeTag) string {
+	switch {
+	case t.Bool != nil:
+		return "bool"
+	case t.Uint8 != nil:
+		return "uint8"
+	case t.Uint16 != nil:
+		return "uint16"
+	case t.Uint32 != nil:
+		return "uint32"
+	case t.Uint64 != nil:
+		return "uint64"
+	case t.Uint128 != nil:
+		m.use("github.com/fardream/go-bcs/bcs")
+		return "bcs.Uint128"
+	case t.Uint256 != nil:
+		return m.aptosType("Uint256")
+	case t.Address != nil:
+		return m.aptosType("Address")
+	case t.TypeParam != nil:
+		return "any"
+	case t.Vector != nil && t.Vector.Uint8 != nil:
+		return "[]byte"
+	case t.Vector != nil:
+		if element := m.argGoType(t.Vector); element != "" {
+			return "[]" + element
+		}
+		return ""
+	case t.Struct != nil:
+		s := t.Struct
+		switch {
+		case isStdStruct(s, "string", "String"):
+			return "string"
+		case isStdStruct(s, "option", "Option") && len(s.GenericTypeParameters) == 1:
+			if value := m.argGoType(s.GenericTypeParameters[0]); value != "" {
+				return "*" + value
+			}
+			return ""
+		case isStdStruct(s, "object", "Object"):
+			return m.aptosType("Address")
+		}
+	}
+
+	return ""
+}
+
+// argConverter is the go function converting the value of [typeMapper.argGoType] to [aptos.EntryFunctionArg].
+// False is returned if the type contains type parameters, and the value must be converted by [aptos.NewEntryFunctionArgWithType].
+func (m *typeMapper) argConverter(t *aptos.MoveTypeTag) (string, bool) {
+	switch {
+	case t.Bool != nil:
+		return m.aptosType("EntryFunctionArg_Bool"), true
+	case t.Uint8 != nil:
+		return m.aptosType("EntryFunctionArg_Uint8"), true
+	case t.Uint16 != nil:
+		return m.aptosType("EntryFunctionArg_Uint16"), true
+	case t.Uint32 != nil:
+		return m.aptosType("EntryFunctionArg_Uint32"), true
+	case t.Uint64 != nil:
+		return m.aptosType("EntryFunctionArg_Uint64"), true
+	case t.Uint128 != nil:
+		return fmt.Sprintf("func(v %s) *aptos.EntryFunctionArg { return &aptos.EntryFunctionArg{Uint128: &v} }", m.argGoType(t)), true
+	case t.Uint256 != nil:
+		return fmt.Sprintf("func(v %s) *aptos.EntryFunctionArg { return aptos.EntryFunctionArg_Uint256(&v) }", m.argGoType(t)), true
+	case t.Address != nil:
+		return m.aptosType("EntryFunctionArg_Address"), true
+	case t.Vector != nil && t.Vector.Uint8 != nil:
+		return m.aptosType("EntryFunctionArg_U8Vector"), true
+	case t.Vector != nil:
+		element, ok := m.argConverter(t.Vector)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("func(v %s) *aptos.EntryFunctionArg { return aptos.EntryFunctionArg_VectorOf(v, %s) }", m.argGoType(t), element), true
+	case t.Struct != nil:
+		s := t.Struct
+		switch {
+		case isStdStruct(s, "string", "String"):
+			return m.aptosType("EntryFunctionArg_String"), true
+		case isStdStruct(s, "option", "Option") && len(s.GenericTypeParameters) == 1:
+			value, ok := m.argConverter(s.GenericTypeParameters[0])
+			if !ok {
+				return "", false
+			}
+			return fmt.Sprintf("func(v %s) *aptos.EntryFunctionArg { return aptos.EntryFunctionArg_OptionOf(v, %s) }", m.argGoType(t), value), true
+		case isStdStruct(s, "object", "Object"):
+			return m.aptosType("EntryFunctionArg_Address"), true
+		}
+	}
+
+	return "", false
+}
+
+// argExpr is the go expression converting the argument of the name to [aptos.EntryFunctionArg].
+// True is returned if the expression returns an error along with the argument.
+func (m *typeMapper) argExpr(t *aptos.MoveTypeTag, name string) (string, bool) {
+	switch converter, ok := m.argConverter(t); {
+	case !ok:
+		return fmt.Sprintf("aptos.NewEntryFunctionArgWithType(%s, %s)", m.typeTagExpr(t), name), true
+	case t.Vector != nil && t.Vector.Uint8 == nil:
+		element, _ := m.argConverter(t.Vector)
+		return fmt.Sprintf("aptos.EntryFunctionArg_VectorOf(%s, %s)", name, element), false
+	case t.Struct != nil && isStdStruct(t.Struct, "option", "Option"):
+		value, _ := m.argConverter(t.Struct.GenericTypeParameters[0])
+		return fmt.Sprintf("aptos.EntryFunctionArg_OptionOf(%s, %s)", name, value), false
+	case t.Uint128 != nil:
+		return fmt.Sprintf("&aptos.EntryFunctionArg{Uint128: &%s}", name), false
+	case t.Uint256 != nil:
+		return fmt.Sprintf("aptos.EntryFunctionArg_Uint256(&%s)", name), false
+	default:
+		return fmt.Sprintf("%s(%s)", converter, name), false
+	}
+}
+
+// typeTagExpr is the go expression of the type, with the type parameters replaced by the type arguments t0, t1, ...
+func (m *typeMapper) typeTagExpr(t *aptos.MoveTypeTag) string {
+	switch {
+	case t.TypeParam != nil:
+		return fmt.Sprintf("t%d", *t.TypeParam)
+	case t.Vector != nil:
+		return fmt.Sprintf("&aptos.MoveTypeTag{Vector: %s}", m.typeTagExpr(t.Vector))
+	case t.Struct != nil:
+		var params []string
+		for _, param := range t.Struct.GenericTypeParameters {
+			params = append(params, m.typeTagExpr(param))
+		}
+		return fmt.Sprintf(
+			"&aptos.MoveTypeTag{Struct: &aptos.MoveStructTag{MoveModuleTag: aptos.MoveModuleTag{Address: aptos.MustParseAddress(%q), Module: %q}, Name: %q, GenericTypeParameters: []*aptos.MoveTypeTag{%s}}}",
+			t.Struct.Address.String(), t.Struct.Module, t.Struct.Name, strings.Join(params, ", "))
+	default:
+		// the rest are the primitive types, for example u64 is &aptos.MoveTypeTag{Uint64: &struct{}{}}.
+		return fmt.Sprintf("&aptos.MoveTypeTag{%s: &struct{}{}}", primitiveTypeTagFields[t.String()])
+	}
+}
+
+var primitiveTypeTagFields = map[string]string{
+	"bool":    "Bool",
+	"u8":      "Uint8",
+	"u16":     "Uint16",
+	"u32":     "Uint32",
+	"u64":     "Uint64",
+	"u128":    "Uint128",
+	"u256":    "Uint256",
+	"address": "Address",
+}
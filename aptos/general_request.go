@@ -15,13 +15,12 @@ type GetLedgerInfoResponse struct {
 	*LedgerInfo `json:",inline"`
 }
 
-// GetChainId
+// GetChainId returns the chain id from the cached ledger info, refreshing the data if it is missing or expired.
 func (client *Client) GetChainId(ctx context.Context) (uint8, error) {
-	if client.ledgerInfo == nil {
-		if err := client.RefreshData(ctx); err != nil {
-			return 0, err
-		}
+	info, err := client.GetCachedLedgerInfo(ctx)
+	if err != nil {
+		return 0, err
 	}
 
-	return uint8(client.ledgerInfo.ChainId), nil
+	return info.ChainId, nil
 }
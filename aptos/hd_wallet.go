@@ -0,0 +1,97 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// AptosDerivationPath returns the path of the account at the index, which is m/44'/637'/index'/0'/0'.
+// Index 0 is [PetraPath].
+func AptosDerivationPath(index uint32) string {
+	return fmt.Sprintf("m/44'/637'/%d'/0'/0'", index)
+}
+
+// HDWallet derives multiple [LocalAccount]s from one mnemonic.
+// Accounts are derived by the index (see [AptosDerivationPath]), or by any hardened bip32 path.
+type HDWallet struct {
+	seed []byte
+}
+
+// NewHDWallet creates a new HD wallet from the mnemonic and the bip39 passphrase, which is usually empty.
+func NewHDWallet(mnemonic string, passphrase string) (*HDWallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bip39 seed: %w", err)
+	}
+
+	return &HDWallet{seed: seed}, nil
+}
+
+// DeriveAccount derives the account at the index. The account at index 0 is the same as [NewLocalAccountFromMnemonic].
+// The index must be less than [HardenedOffset].
+func (w *HDWallet) DeriveAccount(index uint32) (*LocalAccount, error) {
+	if index >= HardenedOffset {
+		return nil, fmt.Errorf("account index %d must be less than %d", index, HardenedOffset)
+	}
+
+	return w.DerivePath(AptosDerivationPath(index))
+}
+
+// DerivePath derives the account at the path. All the segments of the path must be hardened.
+func (w *HDWallet) DerivePath(path string) (*LocalAccount, error) {
+	privateKey, err := Bip32DerivePath(path, w.seed, HardenedOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocalAccountFromPrivateKey(privateKey)
+}
+
+// HDWalletAccount is an account derived from [HDWallet] that exists on chain.
+type HDWalletAccount struct {
+	*LocalAccount
+
+	Index          uint32
+	Path           string
+	SequenceNumber uint64
+}
+
+// ScanAccounts derives the accounts from index start, and returns the accounts that exist on chain.
+// The scan stops after gapLimit consecutive accounts are not found on chain.
+//
+// Accounts are looked up by the address derived from the key, so accounts whose keys have been rotated to the
+// derived keys are not found. See [Client.LookupOriginatingAddress] and [NewLocalAccountWithAddress] for those accounts.
+func (w *HDWallet) ScanAccounts(ctx context.Context, client *Client, start uint32, gapLimit uint32) ([]*HDWalletAccount, error) {
+	var result []*HDWalletAccount
+
+	for index, gap := start, uint32(0); gap < gapLimit; index++ {
+		account, err := w.DeriveAccount(index)
+		if err != nil {
+			return result, err
+		}
+
+		resp, err := client.GetAccount(ctx, &GetAccountRequest{Address: account.Address})
+		var restErr *AptosRestError
+		if errors.As(err, &restErr) && restErr.HttpStatusCode == http.StatusNotFound {
+			gap++
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+
+		gap = 0
+		result = append(result, &HDWalletAccount{
+			LocalAccount:   account,
+			Index:          index,
+			Path:           AptosDerivationPath(index),
+			SequenceNumber: uint64(resp.Parsed.SequenceNumber),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package aptos
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// AccountModuleName is the account module of aptos framework.
+const AccountModuleName = "account"
+
+// Key schemes accepted by 0x1::account::rotate_authentication_key.
+const (
+	RotationScheme_Ed25519      uint8 = 0
+	RotationScheme_MultiEd25519 uint8 = 1
+)
+
+// RotationProofChallenge is the message signed by both the current key and the new key to rotate the authentication key of an account.
+// The bcs encoding of the struct is the same as the [RotationProofChallenge] in the account module.
+//
+// [RotationProofChallenge]: https://github.com/aptos-labs/aptos-core/blob/main/aptos-move/framework/aptos-framework/sources/account.move
+type RotationProofChallenge struct {
+	// AccountAddress, ModuleName, and StructName are the type info of the struct, which is 0x1::account::RotationProofChallenge.
+	AccountAddress Address
+	ModuleName     string
+	StructName     string
+
+	// SequenceNumber is the sequence number of the account when the rotation transaction is executed,
+	// which is the sequence number of the rotation transaction.
+	SequenceNumber uint64
+	// Originator is the address of the account.
+	Originator Address
+	// CurrentAuthKey is the authentication key before the rotation.
+	CurrentAuthKey Address
+	// NewPublicKey is the public key after the rotation.
+	NewPublicKey []byte
+}
+
+// NewRotationProofChallenge creates a new [RotationProofChallenge].
+func NewRotationProofChallenge(sequenceNumber uint64, originator Address, currentAuthKey Address, newPublicKey []byte) *RotationProofChallenge {
+	return &RotationProofChallenge{
+		AccountAddress: AptosStdAddress,
+		ModuleName:     AccountModuleName,
+		StructName:     "RotationProofChallenge",
+		SequenceNumber: sequenceNumber,
+		Originator:     originator,
+		CurrentAuthKey: currentAuthKey,
+		NewPublicKey:   newPublicKey,
+	}
+}
+
+// Account_RotateAuthenticationKey rotates the authentication key of the sender from the current key to the new key.
+// capRotateKey and capUpdateTable are the signatures of the bcs encoded [RotationProofChallenge] by the current key and the new key.
+// For multi ed25519, the public key is the public keys followed by the threshold, and the signature is the signatures followed by the bitmap.
+func Account_RotateAuthenticationKey(
+	sender Address,
+	fromScheme uint8,
+	fromPublicKey []byte,
+	toScheme uint8,
+	toPublicKey []byte,
+	capRotateKey []byte,
+	capUpdateTable []byte,
+	options ...TransactionOption,
+) *Transaction {
+	tx := &Transaction{
+		Payload: NewEntryFunctionPayload(
+			MustNewMoveFunctionTag(AptosStdAddress, AccountModuleName, "rotate_authentication_key"),
+			nil,
+			[]*EntryFunctionArg{
+				EntryFunctionArg_Uint8(fromScheme),
+				EntryFunctionArg_U8Vector(fromPublicKey),
+				EntryFunctionArg_Uint8(toScheme),
+				EntryFunctionArg_U8Vector(toPublicKey),
+				EntryFunctionArg_U8Vector(capRotateKey),
+				EntryFunctionArg_U8Vector(capUpdateTable),
+			},
+		),
+	}
+
+	ApplyTransactionOptions(tx, options...)
+
+	tx.Sender = sender
+
+	return tx
+}
+
+// NewRotateAuthenticationKeyTransaction creates the transaction to rotate the ed25519 key of the account to the new private key.
+// The rotation proof challenge is signed by both keys, and the transaction must be signed by the current key of the account.
+//
+// sequenceNumber must be the sequence number of the account when the transaction is executed, and it is set on the transaction.
+// After the rotation, the account can be recreated with [NewLocalAccountWithAddress], and the address can be looked up from the new key with [Client.LookupOriginatingAddress].
+func NewRotateAuthenticationKeyTransaction(account *LocalAccount, newPrivateKey ed25519.PrivateKey, sequenceNumber uint64, options ...TransactionOption) (*Transaction, error) {
+	newPublicKey, ok := newPrivateKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("cannot get public key from private key")
+	}
+
+	currentAuthKey, err := GenerateAuthenticationKey(1, 1, account.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := bcs.Marshal(NewRotationProofChallenge(sequenceNumber, account.Address, currentAuthKey, newPublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := Account_RotateAuthenticationKey(
+		account.Address,
+		RotationScheme_Ed25519,
+		account.PublicKey,
+		RotationScheme_Ed25519,
+		newPublicKey,
+		ed25519.Sign(account.PrivateKey, challenge),
+		ed25519.Sign(newPrivateKey, challenge),
+		options...,
+	)
+	tx.SequenceNumber = JsonUint64(sequenceNumber)
+
+	return tx, nil
+}
+
+// OriginatingAddress is the resource at 0x1 mapping the authentication keys of the rotated accounts to their addresses.
+type OriginatingAddress struct {
+	AddressMap *Table `json:"address_map"`
+}
+
+// OriginatingAddressType is the type of [OriginatingAddress].
+var OriginatingAddressType = MoveStructTag{
+	MoveModuleTag: MoveModuleTag{
+		Address: AptosStdAddress,
+		Module:  AccountModuleName,
+	},
+	Name: "OriginatingAddress",
+}
+
+// LookupOriginatingAddress finds the address of the account whose authentication key is authKey.
+// If the key of the account has never been rotated, the address is the same as the authentication key,
+// and authKey is returned.
+func (client *Client) LookupOriginatingAddress(ctx context.Context, authKey Address) (Address, error) {
+	originatingAddress, err := GetAccountResourceWithType[OriginatingAddress](ctx, client, AptosStdAddress, &OriginatingAddressType, 0)
+	if err != nil {
+		return Address{}, err
+	}
+	if originatingAddress.AddressMap == nil {
+		return Address{}, fmt.Errorf("missing address map in %s", OriginatingAddressType.String())
+	}
+
+	addressType := &MoveTypeTag{Address: newEmptyStruct()}
+	address, err := GetTableItemWithType[Address](ctx, client, &GetTableItemRequest{
+		Handle:    originatingAddress.AddressMap.Handle,
+		KeyType:   addressType,
+		ValueType: addressType,
+		Key:       authKey,
+	})
+
+	var restErr *AptosRestError
+	if errors.As(err, &restErr) && restErr.HttpStatusCode == http.StatusNotFound {
+		return authKey, nil
+	}
+	if err != nil {
+		return Address{}, err
+	}
+
+	return *address, nil
+}
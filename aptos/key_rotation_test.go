@@ -0,0 +1,100 @@
+package aptos_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func TestNewRotateAuthenticationKeyTransaction(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPublicKey, newPrivateKey, _ := ed25519.GenerateKey(nil)
+
+	tx, err := aptos.NewRotateAuthenticationKeyTransaction(account, newPrivateKey, 12)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if tx.SequenceNumber != 12 || tx.Sender != account.Address {
+		t.Fatalf("wrong sender or sequence number: %s %d", tx.Sender, tx.SequenceNumber)
+	}
+
+	args := tx.Payload.EntryFunctionPayload.Arguments
+	if len(args) != 6 {
+		t.Fatalf("want 6 arguments, got %d", len(args))
+	}
+	if args[1].Vector == nil || args[3].Vector == nil || args[4].Vector == nil || args[5].Vector == nil {
+		t.Fatalf("keys and signatures should be vector<u8>: %v", args)
+	}
+	if _, err := json.Marshal(tx.Payload); err != nil {
+		t.Fatalf("failed to marshal payload to json: %v", err)
+	}
+	capRotateKey, capUpdateTable := *args[4].Vector, *args[5].Vector
+
+	challenge := bcs.MustMarshal(aptos.NewRotationProofChallenge(12, account.Address, account.Address, newPublicKey))
+	if !strings.HasPrefix(string(challenge[32:]), "\x07account\x16RotationProofChallenge\x0c\x00") {
+		t.Fatalf("wrong challenge encoding: %x", challenge)
+	}
+	if !ed25519.Verify(account.PublicKey, challenge, capRotateKey) || !ed25519.Verify(newPublicKey, challenge, capUpdateTable) {
+		t.Fatalf("failed to verify the rotation proof")
+	}
+
+	rotated, err := aptos.NewLocalAccountWithAddress(&newPrivateKey, account.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.Address != account.Address || rotated.IsOriginalAuthenticationKey() {
+		t.Fatalf("rotated account should keep the address")
+	}
+}
+
+func TestClient_LookupOriginatingAddress(t *testing.T) {
+	rotatedAuthKey := aptos.MustParseAddress("0xa")
+	originalAddress := aptos.MustParseAddress("0xb")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/accounts/0x1/resource/"):
+			w.Write([]byte(`{"type": "0x1::account::OriginatingAddress", "data": {"address_map": {"handle": "0x123"}}}`))
+		case r.URL.Path == "/tables/"+aptos.MustParseAddress("0x123").String()+"/item":
+			var body struct {
+				KeyType   string        `json:"key_type"`
+				ValueType string        `json:"value_type"`
+				Key       aptos.Address `json:"key"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.KeyType != "address" || body.ValueType != "address" {
+				t.Errorf("wrong request body: %#v %v", body, err)
+			}
+			if body.Key != rotatedAuthKey {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(originalAddress)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+
+	address, err := client.LookupOriginatingAddress(context.Background(), rotatedAuthKey)
+	if err != nil || address != originalAddress {
+		t.Fatalf("want %s, got %s %v", originalAddress, address, err)
+	}
+
+	notRotated := aptos.MustParseAddress("0xc")
+	address, err = client.LookupOriginatingAddress(context.Background(), notRotated)
+	if err != nil || address != notRotated {
+		t.Fatalf("want %s, got %s %v", notRotated, address, err)
+	}
+}
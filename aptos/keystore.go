@@ -0,0 +1,386 @@
+package aptos
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KeystoreVersion is the version of the keystore file format.
+const KeystoreVersion = 1
+
+// Key derivation functions supported by [Keystore].
+const (
+	KeystoreKdf_Scrypt   = "scrypt"
+	KeystoreKdf_Argon2id = "argon2id"
+)
+
+// KeystoreCipher is the AEAD used to encrypt the private keys in the [Keystore].
+const KeystoreCipher = "xchacha20-poly1305"
+
+// keystoreSaltLength is the length of the random salt of the key derivation function.
+const keystoreSaltLength = 32
+
+// KeystoreKdf contains the parameters of the key derivation function, which derives the encryption key from the passphrase.
+// Parameters that don't apply to the function are omitted.
+type KeystoreKdf struct {
+	Name string `json:"name"`
+	Salt string `json:"salt"`
+
+	// scrypt parameters
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+
+	// argon2id parameters, memory is in KiB.
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// NewScryptKeystoreKdf creates scrypt parameters with a random salt.
+// The recommended parameters are n = 1<<18, r = 8, p = 1.
+func NewScryptKeystoreKdf(n, r, p int) (*KeystoreKdf, error) {
+	salt, err := newKeystoreSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeystoreKdf{Name: KeystoreKdf_Scrypt, Salt: salt, N: n, R: r, P: p}, nil
+}
+
+// NewArgon2idKeystoreKdf creates argon2id parameters with a random salt.
+// The recommended parameters are time = 1, memory = 64 * 1024, threads = 4.
+func NewArgon2idKeystoreKdf(time, memory uint32, threads uint8) (*KeystoreKdf, error) {
+	salt, err := newKeystoreSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeystoreKdf{Name: KeystoreKdf_Argon2id, Salt: salt, Time: time, Memory: memory, Threads: threads}, nil
+}
+
+func newKeystoreSalt() (string, error) {
+	salt := make([]byte, keystoreSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	return prefixedHexString(salt), nil
+}
+
+// deriveKey derives the encryption key from the passphrase.
+func (kdf *KeystoreKdf) deriveKey(passphrase []byte) ([]byte, error) {
+	salt, err := parseHexString(kdf.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse salt: %w", err)
+	}
+
+	switch kdf.Name {
+	case KeystoreKdf_Scrypt:
+		return scrypt.Key(passphrase, salt, kdf.N, kdf.R, kdf.P, chacha20poly1305.KeySize)
+	case KeystoreKdf_Argon2id:
+		if kdf.Time == 0 || kdf.Memory == 0 || kdf.Threads == 0 {
+			return nil, fmt.Errorf("invalid argon2id parameters: time %d, memory %d, threads %d", kdf.Time, kdf.Memory, kdf.Threads)
+		}
+		return argon2.IDKey(passphrase, salt, kdf.Time, kdf.Memory, kdf.Threads, chacha20poly1305.KeySize), nil
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function: %s", kdf.Name)
+	}
+}
+
+// KeystoreEntry is an encrypted [LocalAccount] in the [Keystore].
+// The label, address, public key, and derivation path are in plain text, and the private key is encrypted.
+type KeystoreEntry struct {
+	Label   string  `json:"label"`
+	Address Address `json:"address"`
+	// PublicKey is the hex encoded ed25519 public key.
+	PublicKey string `json:"public_key"`
+	// DerivationPath is the bip32 path if the key is derived from mnemonic, see [Bip32DerivePath].
+	DerivationPath string `json:"derivation_path,omitempty"`
+
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// additionalData binds the ciphertext to the address and the public key, so the encrypted key cannot be moved to another entry.
+func (entry *KeystoreEntry) additionalData() ([]byte, error) {
+	publicKey, err := parseHexString(entry.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key of %s: %w", entry.Label, err)
+	}
+
+	return append(append([]byte{}, entry.Address[:]...), publicKey...), nil
+}
+
+// Keystore stores [LocalAccount]s encrypted by a passphrase.
+//
+// The encryption key is derived from the passphrase by scrypt or argon2id (see [KeystoreKdf]),
+// and each private key is encrypted by XChaCha20-Poly1305 with a random nonce.
+// A keystore loaded from file must be unlocked by [Keystore.Unlock] before the keys can be added or retrieved.
+// Listing, renaming, or removing the entries doesn't need the passphrase.
+type Keystore struct {
+	Version int          `json:"version"`
+	Cipher  string       `json:"cipher"`
+	Kdf     *KeystoreKdf `json:"kdf"`
+	// Check is the encryption of empty data, and is used to verify the passphrase.
+	Check   *KeystoreEntry   `json:"check"`
+	Entries []*KeystoreEntry `json:"entries"`
+
+	key []byte
+}
+
+// NewKeystore creates an empty keystore encrypted by the passphrase with scrypt key derivation.
+func NewKeystore(passphrase []byte) (*Keystore, error) {
+	kdf, err := NewScryptKeystoreKdf(1<<18, 8, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeystoreWithKdf(kdf, passphrase)
+}
+
+// NewKeystoreWithKdf creates an empty keystore encrypted by the passphrase with the key derivation function.
+func NewKeystoreWithKdf(kdf *KeystoreKdf, passphrase []byte) (*Keystore, error) {
+	key, err := kdf.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &Keystore{
+		Version: KeystoreVersion,
+		Cipher:  KeystoreCipher,
+		Kdf:     kdf,
+		Check:   &KeystoreEntry{PublicKey: "0x"},
+		Entries: make([]*KeystoreEntry, 0),
+		key:     key,
+	}
+
+	if err := ks.encrypt(ks.Check, nil); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// LoadKeystore parses the keystore. The keystore is locked.
+func LoadKeystore(data []byte) (*Keystore, error) {
+	ks := &Keystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, err
+	}
+
+	if ks.Version != KeystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version: %d", ks.Version)
+	}
+	if ks.Cipher != KeystoreCipher {
+		return nil, fmt.Errorf("unsupported keystore cipher: %s", ks.Cipher)
+	}
+	if ks.Kdf == nil || ks.Check == nil {
+		return nil, fmt.Errorf("missing key derivation function or check in keystore")
+	}
+	if ks.Entries == nil {
+		ks.Entries = make([]*KeystoreEntry, 0)
+	}
+
+	return ks, nil
+}
+
+// LoadKeystoreFile reads and parses the keystore file. The keystore is locked.
+func LoadKeystoreFile(fileName string) (*Keystore, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadKeystore(data)
+}
+
+// Marshal encodes the keystore into json.
+func (ks *Keystore) Marshal() ([]byte, error) {
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// SaveFile writes the keystore to the file, which is only readable and writable by the owner.
+func (ks *Keystore) SaveFile(fileName string) error {
+	data, err := ks.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, data, 0o600)
+}
+
+// Unlock derives the encryption key from the passphrase, and verifies the passphrase.
+func (ks *Keystore) Unlock(passphrase []byte) error {
+	key, err := ks.Kdf.deriveKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	if _, err := decryptKeystoreEntry(key, ks.Check); err != nil {
+		return fmt.Errorf("wrong passphrase")
+	}
+
+	ks.key = key
+
+	return nil
+}
+
+// IsLocked checks if the keystore needs to be unlocked.
+func (ks *Keystore) IsLocked() bool {
+	return ks.key == nil
+}
+
+func (ks *Keystore) encrypt(entry *KeystoreEntry, plaintext []byte) error {
+	if ks.IsLocked() {
+		return fmt.Errorf("keystore is locked")
+	}
+
+	aead, err := chacha20poly1305.NewX(ks.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	ad, err := entry.additionalData()
+	if err != nil {
+		return err
+	}
+
+	entry.Nonce = prefixedHexString(nonce)
+	entry.Ciphertext = prefixedHexString(aead.Seal(nil, nonce, plaintext, ad))
+
+	return nil
+}
+
+func decryptKeystoreEntry(key []byte, entry *KeystoreEntry) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := parseHexString(entry.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("nonce length is %d, want %d", len(nonce), aead.NonceSize())
+	}
+	ciphertext, err := parseHexString(entry.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	ad, err := entry.additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Open(nil, nonce, ciphertext, ad)
+}
+
+// Find returns the entry with the label, or nil if the label doesn't exist.
+func (ks *Keystore) Find(label string) *KeystoreEntry {
+	for _, entry := range ks.Entries {
+		if entry.Label == label {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// Add encrypts the account and adds it to the keystore with the label. The label must be unique.
+// derivationPath can be empty if the key is not derived from mnemonic.
+func (ks *Keystore) Add(label string, account *LocalAccount, derivationPath string) error {
+	if label == "" {
+		return fmt.Errorf("empty label")
+	}
+	if ks.Find(label) != nil {
+		return fmt.Errorf("label %s already exists", label)
+	}
+
+	entry := &KeystoreEntry{
+		Label:          label,
+		Address:        account.Address,
+		PublicKey:      prefixedHexString(account.PublicKey),
+		DerivationPath: derivationPath,
+	}
+	if err := ks.encrypt(entry, account.PrivateKey.Seed()); err != nil {
+		return err
+	}
+
+	ks.Entries = append(ks.Entries, entry)
+
+	return nil
+}
+
+// Get decrypts the account with the label.
+func (ks *Keystore) Get(label string) (*LocalAccount, error) {
+	if ks.IsLocked() {
+		return nil, fmt.Errorf("keystore is locked")
+	}
+
+	entry := ks.Find(label)
+	if entry == nil {
+		return nil, fmt.Errorf("cannot find %s in keystore", label)
+	}
+
+	seed, err := decryptKeystoreEntry(ks.key, entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", label, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("private key length of %s is %d, want %d", label, len(seed), ed25519.SeedSize)
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	account, err := NewLocalAccountWithAddress(&privateKey, entry.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if publicKey, _ := parseHexString(entry.PublicKey); !bytes.Equal(publicKey, account.PublicKey) {
+		return nil, fmt.Errorf("public key mismatch for %s", label)
+	}
+
+	return account, nil
+}
+
+// Rename changes the label of an entry. The new label must be unique.
+func (ks *Keystore) Rename(label string, newLabel string) error {
+	entry := ks.Find(label)
+	if entry == nil {
+		return fmt.Errorf("cannot find %s in keystore", label)
+	}
+	if newLabel == "" {
+		return fmt.Errorf("empty label")
+	}
+	if ks.Find(newLabel) != nil {
+		return fmt.Errorf("label %s already exists", newLabel)
+	}
+
+	entry.Label = newLabel
+
+	return nil
+}
+
+// Remove deletes the entry with the label.
+func (ks *Keystore) Remove(label string) error {
+	for i, entry := range ks.Entries {
+		if entry.Label == label {
+			ks.Entries = append(ks.Entries[:i], ks.Entries[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot find %s in keystore", label)
+}
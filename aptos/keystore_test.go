@@ -0,0 +1,81 @@
+package aptos_test
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func TestKeystore(t *testing.T) {
+	scryptKdf, err := aptos.NewScryptKeystoreKdf(1<<10, 8, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	argon2idKdf, err := aptos.NewArgon2idKeystoreKdf(1, 1024, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, kdf := range []*aptos.KeystoreKdf{scryptKdf, argon2idKdf} {
+		ks, err := aptos.NewKeystoreWithKdf(kdf, []byte("passphrase"))
+		if err != nil {
+			t.Fatalf("failed to create keystore: %v", err)
+		}
+
+		account, err := aptos.NewLocalAccountWithRandomKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ks.Add("main", account, aptos.PetraPath); err != nil {
+			t.Fatalf("failed to add account: %v", err)
+		}
+		if err := ks.Add("main", account, ""); err == nil {
+			t.Fatalf("duplicated label should fail")
+		}
+		if err := ks.Rename("main", "renamed"); err != nil {
+			t.Fatalf("failed to rename: %v", err)
+		}
+
+		fileName := path.Join(t.TempDir(), "keystore.json")
+		if err := ks.SaveFile(fileName); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+
+		loaded, err := aptos.LoadKeystoreFile(fileName)
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		if !loaded.IsLocked() || len(loaded.Entries) != 1 || loaded.Entries[0].Address != account.Address || loaded.Entries[0].DerivationPath != aptos.PetraPath {
+			t.Fatalf("wrong keystore loaded: %#v", loaded)
+		}
+		if _, err := loaded.Get("renamed"); err == nil {
+			t.Fatalf("locked keystore should fail")
+		}
+		if err := loaded.Unlock([]byte("wrong")); err == nil {
+			t.Fatalf("wrong passphrase should fail")
+		}
+		if err := loaded.Unlock([]byte("passphrase")); err != nil {
+			t.Fatalf("failed to unlock: %v", err)
+		}
+
+		got, err := loaded.Get("renamed")
+		if err != nil {
+			t.Fatalf("failed to get account: %v", err)
+		}
+		if !got.PrivateKey.Equal(account.PrivateKey) || got.Address != account.Address {
+			t.Fatalf("wrong account decrypted")
+		}
+
+		// the encrypted key cannot be moved to another address.
+		loaded.Entries[0].Address = aptos.MustParseAddress("0x1")
+		if _, err := loaded.Get("renamed"); err == nil || !strings.Contains(err.Error(), "failed to decrypt") {
+			t.Fatalf("tampered entry should fail: %v", err)
+		}
+
+		if err := loaded.Remove("renamed"); err != nil || len(loaded.Entries) != 0 {
+			t.Fatalf("failed to remove: %v", err)
+		}
+	}
+}
@@ -122,14 +122,14 @@ func CKDPriv(key *Bip32Key, index uint32) (*Bip32Key, error) {
 // see [corresponding code in typescript]
 //
 // [corresponding code in typescript]: https://github.com/aptos-labs/aptos-core/blob/841a79891dfc9e29b3ffd4c04af285981ff4b8bc/ecosystem/typescript/sdk/src/utils/hd-key.ts#L14
-var pathRegex = regexp.MustCompile(`^m(\/[0-9]+')+`)
+var pathRegex = regexp.MustCompile(`^m(\/[0-9]+')+$`)
 
 // see [corresponding code in typescript]
 //
 // [corresponding code in typescript]: https://github.com/aptos-labs/aptos-core/blob/841a79891dfc9e29b3ffd4c04af285981ff4b8bc/ecosystem/typescript/sdk/src/utils/hd-key.ts#L55-L64
 func IsValidBip32Path(path string) bool {
 	_, err := ParseBip32Path(path)
-	return err != nil
+	return err == nil
 }
 
 // ParseBip32Path
@@ -150,6 +150,10 @@ func ParseBip32Path(path string) ([]uint32, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse %s as uint: %w", index, err)
 		}
+		// the hardened offset is added when deriving, segments at or above it would wrap around.
+		if i >= HardenedOffset {
+			return nil, fmt.Errorf("segment %d of %s must be less than %d", i, path, HardenedOffset)
+		}
 
 		result = append(result, uint32(i))
 	}
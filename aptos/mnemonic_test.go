@@ -1,6 +1,9 @@
 package aptos_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/fardream/go-aptos/aptos"
@@ -20,3 +23,74 @@ func TestFromMnemonic(t *testing.T) {
 		t.Fatalf("not correct public key: %s", localAccount.Address.String())
 	}
 }
+
+func TestIsValidBip32Path(t *testing.T) {
+	for path, want := range map[string]bool{
+		aptos.PetraPath:              true,
+		aptos.AptosDerivationPath(7): true,
+		"m/44'/637'/0'/0'/0":         false,
+		"m/44'/637'/x'":              false,
+		"m":                          false,
+		"m/44'/637'/2147483647'":     true,
+		"m/44'/637'/2147483648'":     false,
+		"m/44'/637'/4294967296'":     false,
+	} {
+		if got := aptos.IsValidBip32Path(path); got != want {
+			t.Errorf("%s: want %t, got %t", path, want, got)
+		}
+	}
+}
+
+func TestHDWallet(t *testing.T) {
+	wallet, err := aptos.NewHDWallet(testCodes, "")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+
+	account, err := wallet.DeriveAccount(0)
+	if err != nil || account.Address.String() != expectedAddress {
+		t.Fatalf("want %s, got %v %v", expectedAddress, account, err)
+	}
+
+	third, err := wallet.DeriveAccount(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wallet.DeriveAccount(aptos.HardenedOffset); err == nil {
+		t.Fatalf("index %d is derived", aptos.HardenedOffset)
+	}
+	if _, err := wallet.DerivePath(aptos.AptosDerivationPath(aptos.HardenedOffset + 2)); err == nil {
+		t.Fatalf("path with out of range segment is derived")
+	}
+
+	byPath, err := wallet.DerivePath("m/44'/637'/2'/0'/0'")
+	if err != nil || byPath.Address != third.Address || third.Address == account.Address {
+		t.Fatalf("wrong account derived by path: %v %v", byPath, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/" + account.Address.String():
+			w.Write([]byte(`{"sequence_number": "3", "authentication_key": "0x1"}`))
+		case "/accounts/" + third.Address.String():
+			w.Write([]byte(`{"sequence_number": "5", "authentication_key": "0x1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+
+	accounts, err := wallet.ScanAccounts(context.Background(), client, 0, 2)
+	if err != nil {
+		t.Fatalf("failed to scan: %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].Index != 0 || accounts[0].SequenceNumber != 3 || accounts[1].Index != 2 || accounts[1].SequenceNumber != 5 {
+		t.Fatalf("wrong accounts: %#v", accounts)
+	}
+
+	if accounts, err := wallet.ScanAccounts(context.Background(), client, 0, 1); err != nil || len(accounts) != 1 {
+		t.Fatalf("scan should stop at the gap: %#v %v", accounts, err)
+	}
+}
@@ -0,0 +1,296 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ModuleABICache loads the [MoveModuleABI] of modules from the node by [Client.GetAccountModule], and keeps them in memory.
+//
+// Modules can be upgraded on chain. Create a new cache or call [ModuleABICache.Remove] to reload the abi of an upgraded module.
+type ModuleABICache struct {
+	client *Client
+
+	mu   sync.RWMutex
+	abis map[string]*MoveModuleABI
+	// phantoms are the phantom-ness of the type parameters of the structs, worked out by PhantomTypeParams.
+	phantoms map[string][]bool
+}
+
+// NewModuleABICache creates a new [ModuleABICache]. client can be nil, in which case all the abis must be added by [ModuleABICache.Add].
+func NewModuleABICache(client *Client) *ModuleABICache {
+	return &ModuleABICache{
+		client:   client,
+		abis:     make(map[string]*MoveModuleABI),
+		phantoms: make(map[string][]bool),
+	}
+}
+
+func moduleABICacheKey(address Address, module string) string {
+	return fmt.Sprintf("%s::%s", address.String(), module)
+}
+
+// Add puts the abi into the cache, for example an abi loaded from a file.
+func (c *ModuleABICache) Add(abi *MoveModuleABI) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.abis[moduleABICacheKey(abi.Address, abi.Name)] = abi
+	c.phantoms = make(map[string][]bool)
+}
+
+// Remove removes the abi of the module from the cache.
+func (c *ModuleABICache) Remove(module *MoveModuleTag) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.abis, moduleABICacheKey(module.Address, module.Module))
+	c.phantoms = make(map[string][]bool)
+}
+
+// Get returns the abi of the module, loading it from the node if it is not in the cache.
+func (c *ModuleABICache) Get(ctx context.Context, module *MoveModuleTag) (*MoveModuleABI, error) {
+	key := moduleABICacheKey(module.Address, module.Module)
+
+	c.mu.RLock()
+	abi, ok := c.abis[key]
+	c.mu.RUnlock()
+	if ok {
+		return abi, nil
+	}
+
+	if c.client == nil {
+		return nil, fmt.Errorf("abi of module %s is not in the cache", module)
+	}
+
+	resp, err := c.client.GetAccountModule(ctx, &GetAccountModuleRequest{Address: module.Address, ModuleName: module.Module})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load module %s: %w", module, err)
+	}
+	if resp.Parsed.Abi == nil {
+		return nil, fmt.Errorf("module %s doesn't have abi", module)
+	}
+
+	c.Add(resp.Parsed.Abi)
+
+	return resp.Parsed.Abi, nil
+}
+
+// GetFunction returns the abi of the function.
+func (c *ModuleABICache) GetFunction(ctx context.Context, function *MoveFunctionTag) (*MoveModuleABI_Function, error) {
+	abi, err := c.Get(ctx, &function.MoveModuleTag)
+	if err != nil {
+		return nil, err
+	}
+
+	f := abi.Function(function.Name)
+	if f == nil {
+		return nil, fmt.Errorf("function %s is not found in the abi", function)
+	}
+
+	return f, nil
+}
+
+// GetStruct returns the abi of the struct.
+func (c *ModuleABICache) GetStruct(ctx context.Context, tag *MoveStructTag) (*MoveModuleABI_Struct, error) {
+	abi, err := c.Get(ctx, &tag.MoveModuleTag)
+	if err != nil {
+		return nil, err
+	}
+
+	s := abi.Struct(tag.Name)
+	if s == nil {
+		return nil, fmt.Errorf("struct %s::%s is not found in the abi", tag.MoveModuleTag.String(), tag.Name)
+	}
+
+	return s, nil
+}
+
+// Abilities returns the abilities of the type, following the rules of move:
+//   - bool, integers and address have copy, drop, and store.
+//   - signer has drop.
+//   - vector has the abilities of its element, except key.
+//   - struct has the declared abilities that all its non-phantom type arguments have (for key, the type arguments must have store).
+//
+// The abis of the structs are loaded, and the structs are validated by [ModuleABICache.ValidateStructTag].
+func (c *ModuleABICache) Abilities(ctx context.Context, t *MoveTypeTag) (MoveAbilitySet, error) {
+	switch {
+	case t.Signer != nil:
+		return MoveAbilitySet{MoveAbility_Drop}, nil
+	case t.Vector != nil:
+		element, err := c.Abilities(ctx, t.Vector)
+		if err != nil {
+			return nil, err
+		}
+		r := MoveAbilitySet{}
+		for _, ability := range element {
+			if ability != MoveAbility_Key {
+				r = append(r, ability)
+			}
+		}
+		return r, nil
+	case t.Struct != nil:
+		return c.structAbilities(ctx, t.Struct)
+	case t.TypeParam != nil:
+		return nil, fmt.Errorf("type parameter %s is not instantiated", t)
+	default:
+		return primitiveAbilities, nil
+	}
+}
+
+func (c *ModuleABICache) structAbilities(ctx context.Context, tag *MoveStructTag) (MoveAbilitySet, error) {
+	s, err := c.GetStruct(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tag.GenericTypeParameters) != len(s.GenericTypeParams) {
+		return nil, fmt.Errorf("%s requires %d type arguments, got %d", tag, len(s.GenericTypeParams), len(tag.GenericTypeParameters))
+	}
+
+	typeArgumentAbilities := make([]MoveAbilitySet, 0, len(tag.GenericTypeParameters))
+	for i, param := range s.GenericTypeParams {
+		typeArgument := tag.GenericTypeParameters[i]
+		abilities, err := c.Abilities(ctx, typeArgument)
+		if err != nil {
+			return nil, err
+		}
+		if !abilities.HasAll(param.Constraints) {
+			return nil, fmt.Errorf("type argument %d (%s) of %s has abilities %v, but requires %v", i, typeArgument, tag, abilities, param.Constraints)
+		}
+		typeArgumentAbilities = append(typeArgumentAbilities, abilities)
+	}
+
+	isPhantom, err := c.PhantomTypeParams(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.InstanceAbilities(typeArgumentAbilities, isPhantom)
+}
+
+// PhantomTypeParams returns whether each type parameter of the struct is phantom. The type arguments of the tag are ignored.
+//
+// The node doesn't return the phantom-ness in the abi, so it is worked out from the fields:
+// a type parameter is phantom if it is not used by the fields, or only used as the type arguments
+// for the phantom type parameters of other structs, which are loaded and checked recursively.
+// [MoveModuleABI_GenericTypeParam.IsPhantom] overrides the result if it is set.
+// The type parameters of native structs are not phantom unless overridden.
+func (c *ModuleABICache) PhantomTypeParams(ctx context.Context, tag *MoveStructTag) ([]bool, error) {
+	return c.phantomTypeParams(ctx, tag, make(map[string]bool))
+}
+
+func (c *ModuleABICache) phantomTypeParams(ctx context.Context, tag *MoveStructTag, visiting map[string]bool) ([]bool, error) {
+	key := fmt.Sprintf("%s::%s", moduleABICacheKey(tag.Address, tag.Module), tag.Name)
+
+	c.mu.RLock()
+	cached, ok := c.phantoms[key]
+	c.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	if visiting[key] {
+		return nil, fmt.Errorf("struct %s contains itself", key)
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	s, err := c.GetStruct(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]bool, len(s.GenericTypeParams))
+	for i := range r {
+		r[i] = !s.IsNative
+	}
+	for _, field := range s.Fields {
+		if err := c.markUsedTypeParams(ctx, field.Type, r, visiting); err != nil {
+			return nil, fmt.Errorf("failed to check field %s of %s: %w", field.Name, key, err)
+		}
+	}
+	for i, param := range s.GenericTypeParams {
+		if param.IsPhantom != nil {
+			r[i] = *param.IsPhantom
+		}
+	}
+
+	c.mu.Lock()
+	c.phantoms[key] = r
+	c.mu.Unlock()
+
+	return r, nil
+}
+
+// markUsedTypeParams sets the type parameters used by the type in non-phantom positions to not phantom.
+func (c *ModuleABICache) markUsedTypeParams(ctx context.Context, t *MoveTypeTag, isPhantom []bool, visiting map[string]bool) error {
+	switch {
+	case t.TypeParam != nil:
+		if int(*t.TypeParam) >= len(isPhantom) {
+			return fmt.Errorf("type parameter %s is out of range", t)
+		}
+		isPhantom[*t.TypeParam] = false
+	case t.Vector != nil:
+		return c.markUsedTypeParams(ctx, t.Vector, isPhantom, visiting)
+	case t.Struct != nil && hasTypeParams(t):
+		// structs without type parameters in the arguments are not loaded.
+		argumentPhantoms, err := c.phantomTypeParams(ctx, t.Struct, visiting)
+		if err != nil {
+			return err
+		}
+		if len(argumentPhantoms) != len(t.Struct.GenericTypeParameters) {
+			return fmt.Errorf("%s requires %d type arguments, got %d", t.Struct, len(argumentPhantoms), len(t.Struct.GenericTypeParameters))
+		}
+		for i, argument := range t.Struct.GenericTypeParameters {
+			if argumentPhantoms[i] {
+				continue
+			}
+			if err := c.markUsedTypeParams(ctx, argument, isPhantom, visiting); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasTypeParams(t *MoveTypeTag) bool {
+	switch {
+	case t.TypeParam != nil:
+		return true
+	case t.Vector != nil:
+		return hasTypeParams(t.Vector)
+	case t.Struct != nil:
+		for _, param := range t.Struct.GenericTypeParameters {
+			if hasTypeParams(param) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidateStructTag checks the struct exists, has the right number of type arguments,
+// and the type arguments satisfy the constraints of the type parameters. The type arguments are checked recursively.
+func (c *ModuleABICache) ValidateStructTag(ctx context.Context, tag *MoveStructTag) error {
+	_, err := c.structAbilities(ctx, tag)
+	return err
+}
+
+// ValidateResourceType checks the struct is valid (see [ModuleABICache.ValidateStructTag]) and has the key ability,
+// therefore can be stored under an account. Use it to check the type before [GetAccountResourceWithType].
+func (c *ModuleABICache) ValidateResourceType(ctx context.Context, tag *MoveStructTag) error {
+	abilities, err := c.structAbilities(ctx, tag)
+	if err != nil {
+		return err
+	}
+
+	if !abilities.Has(MoveAbility_Key) {
+		return fmt.Errorf("%s is not a resource, abilities: %v", tag, abilities)
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package aptos
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // MoveModuleABI is the smart contract module [abi].
 //
@@ -17,19 +20,191 @@ type MoveModuleABI struct {
 
 // MoveModuleABI_Function is the function definition contained in a [MoveModuleABI]
 type MoveModuleABI_Function struct {
-	Name              string            `json:"name"`
-	Visibility        string            `json:"visibility"`
-	IsEntry           bool              `json:"is_entry"`
-	GenericTypeParams []json.RawMessage `json:"generic_type_params"`
-	Params            []string          `json:"params"`
-	Return            []string          `json:"return"`
+	Name              string                            `json:"name"`
+	Visibility        string                            `json:"visibility"`
+	IsEntry           bool                              `json:"is_entry"`
+	IsView            bool                              `json:"is_view"`
+	GenericTypeParams []*MoveModuleABI_GenericTypeParam `json:"generic_type_params"`
+	// Params are kept as strings since they can be references like &signer, which are not [MoveTypeTag].
+	// See [MoveModuleABI_Function.ParamTypes].
+	Params []string `json:"params"`
+	Return []string `json:"return"`
 }
 
 // MoveModuleABI_Struct is the struct definition contained in a [MoveModuleABI]
 type MoveModuleABI_Struct struct {
-	Name              string            `json:"name"`
-	IsNative          bool              `json:"is_native"`
-	Abilities         []string          `json:"abilities"`
-	GenericTypeParams []json.RawMessage `json:"generic_type_params"`
-	Fields            []json.RawMessage `json:"fields"`
+	Name              string                            `json:"name"`
+	IsNative          bool                              `json:"is_native"`
+	Abilities         MoveAbilitySet                    `json:"abilities"`
+	GenericTypeParams []*MoveModuleABI_GenericTypeParam `json:"generic_type_params"`
+	Fields            []*MoveModuleABI_Field            `json:"fields"`
+}
+
+// MoveModuleABI_GenericTypeParam is the generic type parameter of a function or a struct.
+type MoveModuleABI_GenericTypeParam struct {
+	// Constraints are the abilities the type argument must have.
+	Constraints MoveAbilitySet `json:"constraints"`
+	// IsPhantom overrides whether the type parameter of a struct is phantom. Phantom type parameters are not used by the fields,
+	// and don't affect the abilities of the struct.
+	// The node doesn't return it, and [ModuleABICache.PhantomTypeParams] works it out from the fields if it is nil.
+	IsPhantom *bool `json:"is_phantom,omitempty"`
+}
+
+// MoveModuleABI_Field is a field of a struct. The type can contain the generic type parameters of the struct.
+type MoveModuleABI_Field struct {
+	Name string       `json:"name"`
+	Type *MoveTypeTag `json:"type"`
+}
+
+var _ json.Unmarshaler = (*MoveModuleABI_Field)(nil)
+
+// UnmarshalJSON parses the type of the field by [ParseMoveTypeTagWithTypeParams].
+func (f *MoveModuleABI_Field) UnmarshalJSON(data []byte) error {
+	var field struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &field); err != nil {
+		return err
+	}
+
+	fieldType, err := ParseMoveTypeTagWithTypeParams(field.Type)
+	if err != nil {
+		return fmt.Errorf("failed to parse type of field %s: %w", field.Name, err)
+	}
+
+	f.Name = field.Name
+	f.Type = fieldType
+
+	return nil
+}
+
+// MoveAbility is the [ability] of a move type.
+//
+// [ability]: https://aptos.dev/move/book/abilities
+type MoveAbility string
+
+const (
+	MoveAbility_Copy  MoveAbility = "copy"
+	MoveAbility_Drop  MoveAbility = "drop"
+	MoveAbility_Store MoveAbility = "store"
+	MoveAbility_Key   MoveAbility = "key"
+)
+
+var _ json.Unmarshaler = (*MoveAbility)(nil)
+
+func (a *MoveAbility) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	switch MoveAbility(str) {
+	case MoveAbility_Copy, MoveAbility_Drop, MoveAbility_Store, MoveAbility_Key:
+		*a = MoveAbility(str)
+		return nil
+	default:
+		return fmt.Errorf("unknown ability: %s", str)
+	}
+}
+
+// MoveAbilitySet is a set of abilities.
+type MoveAbilitySet []MoveAbility
+
+// Has checks if the set has the ability.
+func (s MoveAbilitySet) Has(ability MoveAbility) bool {
+	for _, a := range s {
+		if a == ability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAll checks if the set has all the abilities in the other set.
+func (s MoveAbilitySet) HasAll(other MoveAbilitySet) bool {
+	for _, a := range other {
+		if !s.Has(a) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// primitiveAbilities are the abilities of the primitive types like integers, bool, and address.
+var primitiveAbilities = MoveAbilitySet{MoveAbility_Copy, MoveAbility_Drop, MoveAbility_Store}
+
+// Function returns the function of the name, or nil if not found.
+func (m *MoveModuleABI) Function(name string) *MoveModuleABI_Function {
+	for _, f := range m.Functions {
+		if f.Name == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// Struct returns the struct of the name, or nil if not found.
+func (m *MoveModuleABI) Struct(name string) *MoveModuleABI_Struct {
+	for _, s := range m.Structs {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// FieldTypes returns the types of the fields with the type parameters of the struct replaced by the type arguments.
+func (s *MoveModuleABI_Struct) FieldTypes(typeArguments []*MoveTypeTag) ([]*MoveTypeTag, error) {
+	if len(typeArguments) != len(s.GenericTypeParams) {
+		return nil, fmt.Errorf("struct %s requires %d type arguments, got %d", s.Name, len(s.GenericTypeParams), len(typeArguments))
+	}
+
+	r := make([]*MoveTypeTag, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		fieldType, err := field.Type.InstantiateTypeParams(typeArguments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to instantiate field %s of %s: %w", field.Name, s.Name, err)
+		}
+		r = append(r, fieldType)
+	}
+
+	return r, nil
+}
+
+// InstanceAbilities calculates the abilities of the struct instantiated with type arguments of the abilities.
+// The struct has an ability if it is declared, and all the non-phantom type arguments have the ability
+// (or store for key). isPhantom is whether each type parameter is phantom, see [ModuleABICache.PhantomTypeParams].
+func (s *MoveModuleABI_Struct) InstanceAbilities(typeArgumentAbilities []MoveAbilitySet, isPhantom []bool) (MoveAbilitySet, error) {
+	if len(typeArgumentAbilities) != len(s.GenericTypeParams) {
+		return nil, fmt.Errorf("struct %s requires %d type arguments, got %d", s.Name, len(s.GenericTypeParams), len(typeArgumentAbilities))
+	}
+	if len(isPhantom) != len(s.GenericTypeParams) {
+		return nil, fmt.Errorf("struct %s has %d type parameters, got phantom-ness of %d", s.Name, len(s.GenericTypeParams), len(isPhantom))
+	}
+
+	r := MoveAbilitySet{}
+	for _, ability := range s.Abilities {
+		required := ability
+		if ability == MoveAbility_Key {
+			required = MoveAbility_Store
+		}
+
+		ok := true
+		for i := range s.GenericTypeParams {
+			if !isPhantom[i] && !typeArgumentAbilities[i].Has(required) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			r = append(r, ability)
+		}
+	}
+
+	return r, nil
 }
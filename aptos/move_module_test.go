@@ -0,0 +1,242 @@
+package aptos_test
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+const testStructsABI = `{
+  "address": "0xcafe",
+  "name": "pool",
+  "friends": [],
+  "exposed_functions": [],
+  "structs": [
+    {
+      "name": "Pool",
+      "is_native": false,
+      "abilities": ["key"],
+      "generic_type_params": [{"constraints": []}, {"constraints": ["store"]}],
+      "fields": [
+        {"name": "reserve", "type": "0x1::coin::Coin<T0>"},
+        {"name": "extra", "type": "vector<T1>"}
+      ]
+    },
+    {
+      "name": "Ticket",
+      "is_native": false,
+      "abilities": ["copy", "drop", "store"],
+      "generic_type_params": [],
+      "fields": [{"name": "id", "type": "u64"}]
+    },
+    {
+      "name": "Receipt",
+      "is_native": false,
+      "abilities": [],
+      "generic_type_params": [],
+      "fields": [{"name": "id", "type": "u64"}]
+    }
+  ]
+}`
+
+const testCoinABI = `{
+  "address": "0x1",
+  "name": "coin",
+  "friends": [],
+  "exposed_functions": [],
+  "structs": [
+    {
+      "name": "Coin",
+      "is_native": false,
+      "abilities": ["store"],
+      "generic_type_params": [{"constraints": []}],
+      "fields": [{"name": "value", "type": "u64"}]
+    }
+  ]
+}`
+
+func newTestStructsCache(t *testing.T) *aptos.ModuleABICache {
+	cache := aptos.NewModuleABICache(nil)
+	for _, data := range []string{testStructsABI, testCoinABI} {
+		abi := &aptos.MoveModuleABI{}
+		if err := json.Unmarshal([]byte(data), abi); err != nil {
+			t.Fatalf("failed to parse abi: %v", err)
+		}
+		cache.Add(abi)
+	}
+
+	return cache
+}
+
+func TestMoveModuleABI_Struct(t *testing.T) {
+	abi := &aptos.MoveModuleABI{}
+	if err := json.Unmarshal([]byte(testStructsABI), abi); err != nil {
+		t.Fatalf("failed to parse abi: %v", err)
+	}
+
+	pool := abi.Struct("Pool")
+	if pool == nil || !pool.Abilities.Has(aptos.MoveAbility_Key) || pool.GenericTypeParams[0].IsPhantom != nil || !pool.GenericTypeParams[1].Constraints.Has(aptos.MoveAbility_Store) {
+		t.Fatalf("wrong struct: %#v", pool)
+	}
+	if pool.Fields[0].Name != "reserve" || pool.Fields[0].Type.String() != "0x1::coin::Coin<T0>" {
+		t.Fatalf("wrong field: %#v", pool.Fields[0])
+	}
+
+	fieldTypes, err := pool.FieldTypes([]*aptos.MoveTypeTag{
+		must(aptos.ParseMoveTypeTag("0x1::aptos_coin::AptosCoin")),
+		must(aptos.ParseMoveTypeTag("u8")),
+	})
+	if err != nil {
+		t.Fatalf("failed to get field types: %v", err)
+	}
+	if fieldTypes[0].String() != "0x1::coin::Coin<0x1::aptos_coin::AptosCoin>" || fieldTypes[1].String() != "vector<u8>" {
+		t.Fatalf("wrong field types: %v", fieldTypes)
+	}
+
+	if _, err := pool.FieldTypes(nil); err == nil {
+		t.Fatalf("field types are instantiated without type arguments")
+	}
+
+	if err := json.Unmarshal([]byte(`{"name": "Bad", "abilities": ["clone"]}`), &aptos.MoveModuleABI_Struct{}); err == nil {
+		t.Fatalf("unknown ability is accepted")
+	}
+}
+
+func TestModuleABICache_Abilities(t *testing.T) {
+	cache := newTestStructsCache(t)
+
+	cases := []struct {
+		typeStr string
+		want    aptos.MoveAbilitySet
+	}{
+		{typeStr: "u64", want: aptos.MoveAbilitySet{aptos.MoveAbility_Copy, aptos.MoveAbility_Drop, aptos.MoveAbility_Store}},
+		{typeStr: "signer", want: aptos.MoveAbilitySet{aptos.MoveAbility_Drop}},
+		{typeStr: "vector<0xcafe::pool::Ticket>", want: aptos.MoveAbilitySet{aptos.MoveAbility_Copy, aptos.MoveAbility_Drop, aptos.MoveAbility_Store}},
+		{typeStr: "vector<0xcafe::pool::Receipt>", want: aptos.MoveAbilitySet{}},
+		{typeStr: "0x1::coin::Coin<0xcafe::pool::Receipt>", want: aptos.MoveAbilitySet{aptos.MoveAbility_Store}},
+		{typeStr: "0xcafe::pool::Pool<0xcafe::pool::Receipt, 0xcafe::pool::Ticket>", want: aptos.MoveAbilitySet{aptos.MoveAbility_Key}},
+	}
+
+	for _, c := range cases {
+		got, err := cache.Abilities(context.Background(), must(aptos.ParseMoveTypeTag(c.typeStr)))
+		if err != nil {
+			t.Errorf("failed to get abilities of %s: %v", c.typeStr, err)
+			continue
+		}
+		if len(got) != len(c.want) || !got.HasAll(c.want) {
+			t.Errorf("abilities of %s:\nwant: %v\ngot:  %v", c.typeStr, c.want, got)
+		}
+	}
+}
+
+func TestModuleABICache_ValidateStructTag(t *testing.T) {
+	cache := newTestStructsCache(t)
+	ctx := context.Background()
+
+	if err := cache.ValidateResourceType(ctx, must(aptos.ParseMoveStructTag("0xcafe::pool::Pool<0xcafe::pool::Receipt, u8>"))); err != nil {
+		t.Fatalf("valid resource is rejected: %v", err)
+	}
+
+	cases := []struct {
+		typeStr string
+		errStr  string
+	}{
+		{typeStr: "0xcafe::pool::Pool<u8>", errStr: "requires 2 type arguments, got 1"},
+		{typeStr: "0xcafe::pool::Pool<u8, 0xcafe::pool::Receipt>", errStr: "but requires [store]"},
+		{typeStr: "0xcafe::pool::Pool<u8, vector<0x1::coin::Coin<u8, u8>>>", errStr: "0x1::coin::Coin<u8,u8> requires 1 type arguments, got 2"},
+		{typeStr: "0xcafe::pool::Missing", errStr: "is not found"},
+		{typeStr: "0xcafe::pool::Ticket", errStr: "is not a resource"},
+	}
+
+	for _, c := range cases {
+		err := cache.ValidateResourceType(ctx, must(aptos.ParseMoveStructTag(c.typeStr)))
+		if err == nil || !strings.Contains(err.Error(), c.errStr) {
+			t.Errorf("validating %s:\nwant error containing: %s\ngot: %v", c.typeStr, c.errStr, err)
+		}
+	}
+
+	if err := cache.ValidateStructTag(ctx, must(aptos.ParseMoveStructTag("0xcafe::pool::Ticket"))); err != nil {
+		t.Fatalf("valid struct is rejected: %v", err)
+	}
+}
+
+func TestModuleABICache_PhantomTypeParams(t *testing.T) {
+	cache := newTestStructsCache(t)
+	ctx := context.Background()
+
+	// T0 of Pool is only used by the phantom T0 of Coin, T1 is used by a vector.
+	isPhantom, err := cache.PhantomTypeParams(ctx, must(aptos.ParseMoveStructTag("0xcafe::pool::Pool<u8, u8>")))
+	if err != nil || len(isPhantom) != 2 || !isPhantom[0] || isPhantom[1] {
+		t.Fatalf("wrong phantom type parameters of Pool: %v %v", isPhantom, err)
+	}
+
+	override := &aptos.MoveModuleABI{}
+	if err := json.Unmarshal([]byte(`{
+  "address": "0xcafe",
+  "name": "wrapper",
+  "structs": [
+    {
+      "name": "Wrapper",
+      "abilities": ["store"],
+      "generic_type_params": [{"constraints": [], "is_phantom": false}],
+      "fields": [{"name": "coin", "type": "0x1::coin::Coin<T0>"}]
+    }
+  ]
+}`), override); err != nil {
+		t.Fatalf("failed to parse abi: %v", err)
+	}
+	cache.Add(override)
+	if isPhantom, err := cache.PhantomTypeParams(ctx, must(aptos.ParseMoveStructTag("0xcafe::wrapper::Wrapper<u8>"))); err != nil || isPhantom[0] {
+		t.Fatalf("is_phantom doesn't override: %v %v", isPhantom, err)
+	}
+}
+
+var (
+	//go:embed test_data/coin_module.json
+	testNodeCoinModule []byte
+	//go:embed test_data/aptos_coin_module.json
+	testNodeAptosCoinModule []byte
+)
+
+// TestModuleABICache_NodeABI loads the abis in the shape returned by the node, which don't have is_phantom.
+func TestModuleABICache_NodeABI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/0x1/module/coin":
+			w.Write(testNodeCoinModule)
+		case "/accounts/0x1/module/aptos_coin":
+			w.Write(testNodeAptosCoinModule)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cache := aptos.NewModuleABICache(aptos.MustNewClient(aptos.Localnet, server.URL))
+	ctx := context.Background()
+
+	for _, typeStr := range []string{
+		"0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin>",
+		"0x1::coin::CoinInfo<0x1::aptos_coin::AptosCoin>",
+		"0x1::aptos_coin::MintCapStore",
+	} {
+		if err := cache.ValidateResourceType(ctx, must(aptos.ParseMoveStructTag(typeStr))); err != nil {
+			t.Errorf("%s is rejected: %v", typeStr, err)
+		}
+	}
+
+	abilities, err := cache.Abilities(ctx, must(aptos.ParseMoveTypeTag("0x1::coin::Coin<0x1::aptos_coin::AptosCoin>")))
+	if err != nil || len(abilities) != 1 || !abilities.Has(aptos.MoveAbility_Store) {
+		t.Fatalf("wrong abilities of coin: %v %v", abilities, err)
+	}
+
+	if err := cache.ValidateResourceType(ctx, must(aptos.ParseMoveStructTag("0x1::coin::Coin<0x1::aptos_coin::AptosCoin>"))); err == nil {
+		t.Fatalf("coin is accepted as a resource")
+	}
+}
@@ -0,0 +1,207 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// MovePackageMetadata is the on chain metadata of a move package, 0x1::code::PackageMetadata.
+// The metadata is generated by the move compiler into package-metadata.bcs in the build directory.
+//
+// See [code.move].
+//
+// [code.move]: https://github.com/aptos-labs/aptos-core/blob/main/aptos-move/framework/aptos-framework/sources/code.move
+type MovePackageMetadata struct {
+	Name          string
+	UpgradePolicy uint8
+	UpgradeNumber uint64
+	SourceDigest  string
+	Manifest      []byte
+	Modules       []MoveModuleMetadata
+	Deps          []MovePackageDep
+	Extension     []MoveAny // Option<Any>
+}
+
+// MoveModuleMetadata is the metadata of a module in the package, 0x1::code::ModuleMetadata.
+type MoveModuleMetadata struct {
+	Name      string
+	Source    []byte
+	SourceMap []byte
+	Extension []MoveAny // Option<Any>
+}
+
+// MovePackageDep is a dependency of the package, 0x1::code::PackageDep.
+type MovePackageDep struct {
+	Account     Address
+	PackageName string
+}
+
+// MoveAny is 0x1::copyable_any::Any.
+type MoveAny struct {
+	TypeName string
+	Data     []byte
+}
+
+// MovePackage contains the metadata and the compiled modules of a move package, ready to be published.
+type MovePackage struct {
+	// MetadataBytes is the bcs encoded [MovePackageMetadata].
+	MetadataBytes []byte
+	// Metadata decoded from MetadataBytes.
+	Metadata *MovePackageMetadata
+	// Modules are the bytecode of the modules, in the same order as Metadata.Modules.
+	Modules []MoveBytecode
+}
+
+const (
+	movePackageMetadataFileName = "package-metadata.bcs"
+	moveBytecodeModulesDirName  = "bytecode_modules"
+)
+
+// LoadMovePackage loads the compiled package from the build directory of the package,
+// which is build/<PackageName> under the package root after running "aptos move compile --save-metadata".
+//
+// The directory must contain package-metadata.bcs and bytecode_modules/<module>.mv.
+// The modules are ordered according to the metadata, which is the dependency order produced by the compiler.
+// Modules of the dependencies (in bytecode_modules/dependencies) are not included.
+func LoadMovePackage(buildDir string) (*MovePackage, error) {
+	metadataBytes, err := os.ReadFile(filepath.Join(buildDir, movePackageMetadataFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package metadata, make sure the package is compiled with --save-metadata: %w", err)
+	}
+
+	metadata := &MovePackageMetadata{}
+	if _, err := bcs.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode package metadata: %w", err)
+	}
+
+	pkg := &MovePackage{
+		MetadataBytes: metadataBytes,
+		Metadata:      metadata,
+	}
+
+	for _, module := range metadata.Modules {
+		code, err := os.ReadFile(filepath.Join(buildDir, moveBytecodeModulesDirName, module.Name+".mv"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bytecode for module %s: %w", module.Name, err)
+		}
+		pkg.Modules = append(pkg.Modules, code)
+	}
+
+	return pkg, nil
+}
+
+// FindMovePackageBuildDir finds the build directory under the package root (which contains Move.toml).
+// If the package name is empty and there is only one package in the build directory, that package is returned.
+func FindMovePackageBuildDir(packageDir string, packageName string) (string, error) {
+	if packageName != "" {
+		return filepath.Join(packageDir, "build", packageName), nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(packageDir, "build", "*", movePackageMetadataFileName))
+	if err != nil {
+		return "", err
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no compiled package found in %s, make sure the package is compiled with --save-metadata", filepath.Join(packageDir, "build"))
+	case 1:
+		return filepath.Dir(matches[0]), nil
+	default:
+		names := mapSlices(matches, func(m string) string { return filepath.Base(filepath.Dir(m)) })
+		return "", fmt.Errorf("multiple packages found: %s, specify the package name", strings.Join(names, ", "))
+	}
+}
+
+// NewPublishPackagePayload creates the payload to call 0x1::code::publish_package_txn, which publishes or upgrades a package.
+func NewPublishPackagePayload(pkg *MovePackage) *TransactionPayload {
+	return NewEntryFunctionPayload(
+		MustNewMoveFunctionTag(AptosStdAddress, "code", "publish_package_txn"),
+		nil,
+		[]*EntryFunctionArg{
+			EntryFunctionArg_U8Vector(pkg.MetadataBytes),
+			EntryFunctionArg_VectorOf(pkg.Modules, func(m MoveBytecode) *EntryFunctionArg { return EntryFunctionArg_U8Vector(m) }),
+		},
+	)
+}
+
+// NewPublishPackageTransaction creates a transaction to publish the package to the sender's account.
+func NewPublishPackageTransaction(sender Address, pkg *MovePackage, options ...TransactionOption) *Transaction {
+	tx := &Transaction{Payload: NewPublishPackagePayload(pkg)}
+
+	ApplyTransactionOptions(tx, options...)
+
+	tx.Sender = sender
+
+	return tx
+}
+
+// DefaultGasEstimateMargin is the margin applied to the gas used in simulation when the max gas amount is estimated.
+const DefaultGasEstimateMargin = 1.5
+
+// EstimateGasAmount simulates the transaction with the max gas amount affordable by the sender,
+// and returns the gas used multiplied by margin. The transaction must have its data filled (see [Client.FillTransactionData]).
+// An error is returned if the simulation fails.
+func (client *Client) EstimateGasAmount(ctx context.Context, signer Signer, tx *Transaction, margin float64) (uint64, error) {
+	simulationTx, err := SignTransactionForSimulation(signer, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.SimulateSignedTransactionBCS(ctx, &SimulateSignedTransactionBCSRequest{
+		SignedTransaction:    simulationTx,
+		EstimateMaxGasAmount: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(*resp.Parsed) == 0 {
+		return 0, fmt.Errorf("empty simulation result")
+	}
+	result := (*resp.Parsed)[0]
+	if result.TransactionWithInfo == nil || result.TransactionInfo == nil {
+		return 0, fmt.Errorf("simulation result doesn't contain transaction info: %s", string(resp.RawData))
+	}
+	if !result.Success {
+		return 0, fmt.Errorf("simulation failed: %s", result.VmStatus)
+	}
+
+	return uint64(float64(result.GasUsed) * margin), nil
+}
+
+// PublishPackage publishes the package to the account of the signer.
+// The max gas amount of the transaction is estimated by simulation with [DefaultGasEstimateMargin],
+// then the transaction is signed and submitted in bcs. The transaction is waited for unless noWait is true.
+func (client *Client) PublishPackage(ctx context.Context, signer Signer, pkg *MovePackage, noWait bool, options ...TransactionOption) (*TransactionWithInfo, error) {
+	tx := NewPublishPackageTransaction(signer.SignerAddress(), pkg, options...)
+	if err := client.FillTransactionData(ctx, tx, false); err != nil {
+		return nil, err
+	}
+
+	gasAmount, err := client.EstimateGasAmount(ctx, signer, tx, DefaultGasEstimateMargin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	tx.MaxGasAmount = JsonUint64(gasAmount)
+
+	signedTx, err := SignTransaction(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.SubmitSignedTransactionBCS(ctx, signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if noWait {
+		return resp.Parsed.TransactionWithInfo, nil
+	}
+
+	return client.WaitForTransaction(ctx, resp.Parsed.Hash)
+}
@@ -0,0 +1,134 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// writeTestMovePackage writes a compiled package with modules b and a, where a depends on b.
+func writeTestMovePackage(t *testing.T) string {
+	t.Helper()
+
+	packageDir := t.TempDir()
+	buildDir := filepath.Join(packageDir, "build", "TestPackage")
+	if err := os.MkdirAll(filepath.Join(buildDir, "bytecode_modules", "dependencies"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := &aptos.MovePackageMetadata{
+		Name:          "TestPackage",
+		UpgradePolicy: 1,
+		SourceDigest:  "ABCD",
+		Manifest:      []byte("[package]"),
+		Modules:       []aptos.MoveModuleMetadata{{Name: "b"}, {Name: "a"}},
+		Deps:          []aptos.MovePackageDep{{Account: aptos.AptosStdAddress, PackageName: "AptosFramework"}},
+	}
+	files := map[string][]byte{
+		"package-metadata.bcs":  bcs.MustMarshal(metadata),
+		"bytecode_modules/a.mv": {0xa1, 0x1c, 0xeb, 0x0b, 0x0a},
+		"bytecode_modules/b.mv": {0xa1, 0x1c, 0xeb, 0x0b, 0x0b},
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(buildDir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return packageDir
+}
+
+func TestLoadMovePackage(t *testing.T) {
+	packageDir := writeTestMovePackage(t)
+
+	buildDir, err := aptos.FindMovePackageBuildDir(packageDir, "")
+	if err != nil {
+		t.Fatalf("failed to find build dir: %v", err)
+	}
+
+	pkg, err := aptos.LoadMovePackage(buildDir)
+	if err != nil {
+		t.Fatalf("failed to load package: %v", err)
+	}
+
+	if pkg.Metadata.Name != "TestPackage" || len(pkg.Modules) != 2 {
+		t.Fatalf("wrong package: %#v", pkg.Metadata)
+	}
+	if pkg.Modules[0][4] != 0x0b || pkg.Modules[1][4] != 0x0a {
+		t.Fatalf("modules are not in the order of metadata")
+	}
+
+	payload := aptos.NewPublishPackagePayload(pkg)
+	args := payload.EntryFunctionPayload.Arguments
+	// vector<vector<u8>> with 2 modules of 5 bytes, prefixed with its own length.
+	wantCode := []byte{13, 2, 5, 0xa1, 0x1c, 0xeb, 0x0b, 0x0b, 5, 0xa1, 0x1c, 0xeb, 0x0b, 0x0a}
+	if got := bcs.MustMarshal(args[1]); !bytes.Equal(got, wantCode) {
+		t.Fatalf("wrong code argument:\nwant: %x\ngot:  %x", wantCode, got)
+	}
+	if _, err := json.Marshal(payload); err != nil {
+		t.Fatalf("failed to marshal payload to json: %v", err)
+	}
+}
+
+func TestClient_PublishPackage(t *testing.T) {
+	pkg, err := aptos.LoadMovePackage(filepath.Join(writeTestMovePackage(t), "build", "TestPackage"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, _ := aptos.NewLocalAccountWithRandomKey()
+
+	var submitted aptos.SignedTransaction
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/estimate_gas_price":
+			w.Write([]byte(`{"gas_estimate": 100}`))
+		case r.URL.Path == "/":
+			w.Write([]byte(testLedgerInfoJson))
+		case strings.HasPrefix(r.URL.Path, "/accounts/"):
+			w.Write([]byte(`{"sequence_number": "3", "authentication_key": "0x00"}`))
+		case r.URL.Path == "/transactions/simulate":
+			if r.URL.Query().Get("estimate_max_gas_amount") != "true" {
+				t.Errorf("max gas amount should be estimated")
+			}
+			w.Write([]byte(`[{"type": "user_transaction", "success": true, "vm_status": "Executed successfully", "gas_used": "1000"}]`))
+		case r.URL.Path == "/transactions":
+			body, _ := io.ReadAll(r.Body)
+			if _, err := bcs.Unmarshal(body, &submitted); err != nil {
+				t.Errorf("failed to decode submitted transaction: %v", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"type": "pending_transaction", "hash": "0x01"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+
+	tx, err := client.PublishPackage(context.Background(), account, pkg, true)
+	if err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	if tx.Hash != "0x01" {
+		t.Fatalf("wrong transaction: %#v", tx)
+	}
+
+	if submitted.MaxGasAmount != 1500 || submitted.SequenceNumber != 3 || submitted.Sender != account.Address {
+		t.Fatalf("wrong submitted transaction: %#v", submitted.Transaction)
+	}
+	if submitted.Payload.Function.String() != "0x1::code::publish_package_txn" {
+		t.Fatalf("wrong function: %s", submitted.Payload.Function)
+	}
+}
@@ -81,7 +81,7 @@ func makeCanonicalSegment(input string) string {
 	return whiteSpaceRegex.ReplaceAllString(input, "")
 }
 
-func parseMoveStructTagInternal(fullName string, moveTypeTag *MoveStructTag) error {
+func parseMoveStructTagInternal(fullName string, moveTypeTag *MoveStructTag, allowTypeParams bool) error {
 	name := makeCanonicalSegment(fullName)
 
 	var segments []string
@@ -91,7 +91,7 @@ func parseMoveStructTagInternal(fullName string, moveTypeTag *MoveStructTag) err
 	if len(genericMatches) == 3 {
 		name = genericMatches[1]
 		var err error
-		genericParameters, err = parseGenericTypeListString(genericMatches[2])
+		genericParameters, err = parseGenericTypeListString(genericMatches[2], allowTypeParams)
 		if err != nil {
 			return err
 		}
@@ -126,14 +126,14 @@ func parseMoveStructTagInternal(fullName string, moveTypeTag *MoveStructTag) err
 // ParseMoveStructTag takes the full name of the move type tag
 func ParseMoveStructTag(fullName string) (*MoveStructTag, error) {
 	r := &MoveStructTag{}
-	if err := parseMoveStructTagInternal(fullName, r); err != nil {
+	if err := parseMoveStructTagInternal(fullName, r, false); err != nil {
 		return nil, err
 	}
 
 	return r, nil
 }
 
-func parseGenericTypeListString(genericTypeListString string) ([]*MoveTypeTag, error) {
+func parseGenericTypeListString(genericTypeListString string, allowTypeParams bool) ([]*MoveTypeTag, error) {
 	leftBracketCount := 0
 	var parsedTypes []*MoveTypeTag
 	start := 0
@@ -153,8 +153,8 @@ func parseGenericTypeListString(genericTypeListString string) ([]*MoveTypeTag, e
 			if leftBracketCount == 0 {
 				end = idx
 				aTypeStr := genericTypeListString[start:end]
-				aType, err := ParseMoveTypeTag(aTypeStr)
-				if err != nil {
+				aType := &MoveTypeTag{}
+				if err := aType.unmarshalFromStr(aTypeStr, allowTypeParams); err != nil {
 					return nil, err
 				}
 				parsedTypes = append(parsedTypes, aType)
@@ -165,8 +165,8 @@ func parseGenericTypeListString(genericTypeListString string) ([]*MoveTypeTag, e
 	}
 
 	if end < l-1 {
-		aType, err := ParseMoveTypeTag(genericTypeListString[start:l])
-		if err != nil {
+		aType := &MoveTypeTag{}
+		if err := aType.unmarshalFromStr(genericTypeListString[start:l], allowTypeParams); err != nil {
 			return nil, err
 		}
 		parsedTypes = append(parsedTypes, aType)
@@ -199,7 +199,7 @@ func (t *MoveStructTag) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	return parseMoveStructTagInternal(dataStr, t)
+	return parseMoveStructTagInternal(dataStr, t, false)
 }
 
 // Type is to support cobra value
@@ -209,5 +209,19 @@ func (t *MoveStructTag) Type() string {
 
 // Set is to support cobra value
 func (t *MoveStructTag) Set(data string) error {
-	return parseMoveStructTagInternal(data, t)
+	return parseMoveStructTagInternal(data, t, false)
+}
+
+// decodeMoveStructTag decodes a struct tag from bcs.
+func decodeMoveStructTag(b *bcsReader) *MoveStructTag {
+	r := &MoveStructTag{}
+	b.decode(&r.Address)
+	b.decode(&r.Module)
+	b.decode(&r.Name)
+	count := b.uleb128()
+	for i := 0; i < count && b.err == nil; i++ {
+		r.GenericTypeParameters = append(r.GenericTypeParameters, decodeMoveTypeTag(b))
+	}
+
+	return r
 }
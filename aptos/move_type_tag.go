@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 
 	"github.com/fardream/go-bcs/bcs"
 )
@@ -13,12 +14,19 @@ import (
 // aptos right now supports the following types
 // - bool
 // - u8
+// - u16
+// - u32
 // - u64
 // - u128
+// - u256
 // - address
 // - signer
 // - vector
 // - struct
+//
+// TypeParam is the generic type parameter T0, T1, ... in the types of the [MoveModuleABI], which is not a valid type on chain.
+// It is only parsed by [ParseMoveTypeTagWithTypeParams], and cannot be marshaled to bcs.
+// Use [MoveTypeTag.InstantiateTypeParams] to replace them with the type arguments.
 type MoveTypeTag struct {
 	Bool    *struct{}      // 0
 	Uint8   *struct{}      // 1
@@ -28,16 +36,36 @@ type MoveTypeTag struct {
 	Signer  *struct{}      // 5
 	Vector  *MoveTypeTag   // 6
 	Struct  *MoveStructTag // 7
+	Uint16  *struct{}      // 8
+	Uint32  *struct{}      // 9
+	Uint256 *struct{}      // 10
+
+	TypeParam *uint16 `bcs:"-"`
 }
 
 var (
 	_ bcs.Enum         = (*MoveTypeTag)(nil)
+	_ bcs.Marshaler    = (*MoveTypeTag)(nil)
 	_ json.Marshaler   = (*MoveTypeTag)(nil)
 	_ json.Unmarshaler = (*MoveTypeTag)(nil)
 )
 
 func (m MoveTypeTag) IsBcsEnum() {}
 
+// moveTypeTagEnum has the same layout as [MoveTypeTag], and is marshaled by the default [bcs.Enum] encoding.
+type moveTypeTagEnum MoveTypeTag
+
+func (m moveTypeTagEnum) IsBcsEnum() {}
+
+// MarshalBCS marshals the type tag as a [bcs.Enum], and returns an error if it is or contains a type parameter.
+func (m MoveTypeTag) MarshalBCS() ([]byte, error) {
+	if m.TypeParam != nil {
+		return nil, fmt.Errorf("type parameter T%d cannot be marshaled to bcs", *m.TypeParam)
+	}
+
+	return bcs.Marshal(moveTypeTagEnum(m))
+}
+
 func (m MoveTypeTag) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.String())
 }
@@ -61,6 +89,12 @@ func (m MoveTypeTag) MarshalText() ([]byte, error) {
 		return []byte("u64"), nil
 	case m.Uint128 != nil:
 		return []byte("u128"), nil
+	case m.Uint16 != nil:
+		return []byte("u16"), nil
+	case m.Uint32 != nil:
+		return []byte("u32"), nil
+	case m.Uint256 != nil:
+		return []byte("u256"), nil
 	case m.Address != nil:
 		return []byte("address"), nil
 	case m.Signer != nil:
@@ -69,6 +103,8 @@ func (m MoveTypeTag) MarshalText() ([]byte, error) {
 		return []byte(fmt.Sprintf("vector<%s>", m.Vector)), nil
 	case m.Struct != nil:
 		return []byte(m.Struct.String()), nil
+	case m.TypeParam != nil:
+		return []byte(fmt.Sprintf("T%d", *m.TypeParam)), nil
 	default:
 		return nil, fmt.Errorf("-- unset move type tag --")
 	}
@@ -87,6 +123,10 @@ func (m *MoveTypeTag) reset() {
 	m.Signer = nil
 	m.Vector = nil
 	m.Struct = nil
+	m.Uint16 = nil
+	m.Uint32 = nil
+	m.Uint256 = nil
+	m.TypeParam = nil
 }
 
 func (m *MoveTypeTag) UnmarshalJSON(data []byte) error {
@@ -95,12 +135,16 @@ func (m *MoveTypeTag) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	return m.unmarshalFromStr(str)
+	return m.unmarshalFromStr(str, false)
 }
 
-var vectorRegex = regexp.MustCompile("^vector<(.+)>$")
+var (
+	vectorRegex    = regexp.MustCompile("^vector<(.+)>$")
+	typeParamRegex = regexp.MustCompile(`^T(\d+)$`)
+)
 
-func (m *MoveTypeTag) unmarshalFromStr(str string) error {
+// unmarshalFromStr parses the type. Type parameters T0, T1, ... are only allowed if allowTypeParams is true.
+func (m *MoveTypeTag) unmarshalFromStr(str string, allowTypeParams bool) error {
 	m.reset()
 	switch str {
 	case "bool":
@@ -115,6 +159,15 @@ func (m *MoveTypeTag) unmarshalFromStr(str string) error {
 	case "u128":
 		m.Uint128 = newEmptyStruct()
 		return nil
+	case "u16":
+		m.Uint16 = newEmptyStruct()
+		return nil
+	case "u32":
+		m.Uint32 = newEmptyStruct()
+		return nil
+	case "u256":
+		m.Uint256 = newEmptyStruct()
+		return nil
 	case "address":
 		m.Address = newEmptyStruct()
 		return nil
@@ -123,15 +176,28 @@ func (m *MoveTypeTag) unmarshalFromStr(str string) error {
 		return nil
 	}
 
+	if typeParamData := typeParamRegex.FindStringSubmatch(str); len(typeParamData) == 2 {
+		if !allowTypeParams {
+			return fmt.Errorf("type parameter %s is not allowed", str)
+		}
+		index, err := strconv.ParseUint(typeParamData[1], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid type parameter %s: %w", str, err)
+		}
+		m.TypeParam = new(uint16)
+		*m.TypeParam = uint16(index)
+		return nil
+	}
+
 	vectorData := vectorRegex.FindStringSubmatch(str)
 
 	if len(vectorData) == 2 {
 		m.Vector = new(MoveTypeTag)
-		return m.Vector.unmarshalFromStr(vectorData[1])
+		return m.Vector.unmarshalFromStr(vectorData[1], allowTypeParams)
 	}
 
-	structTag, err := ParseMoveStructTag(str)
-	if err != nil {
+	structTag := &MoveStructTag{}
+	if err := parseMoveStructTagInternal(str, structTag, allowTypeParams); err != nil {
 		return fmt.Errorf("exhausted all options, and parsing as struct tag failed: %w", err)
 	}
 
@@ -141,14 +207,98 @@ func (m *MoveTypeTag) unmarshalFromStr(str string) error {
 }
 
 func (m *MoveTypeTag) UnmarshalTEXT(data []byte) error {
-	return m.unmarshalFromStr(string(data))
+	return m.unmarshalFromStr(string(data), false)
 }
 
+// ParseMoveTypeTag parses the type. Generic type parameters like T0 are rejected, see [ParseMoveTypeTagWithTypeParams].
 func ParseMoveTypeTag(str string) (*MoveTypeTag, error) {
 	r := &MoveTypeTag{}
-	if err := r.unmarshalFromStr(str); err != nil {
+	if err := r.unmarshalFromStr(str, false); err != nil {
 		return nil, err
 	} else {
 		return r, nil
 	}
 }
+
+// ParseMoveTypeTagWithTypeParams parses the type in the [MoveModuleABI], which can contain the generic type parameters T0, T1, ...
+func ParseMoveTypeTagWithTypeParams(str string) (*MoveTypeTag, error) {
+	r := &MoveTypeTag{}
+	if err := r.unmarshalFromStr(str, true); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// decodeMoveTypeTag decodes a type tag from bcs. [bcs.Decoder] cannot decode nested enums like vector<vector<u8>> by reflection.
+func decodeMoveTypeTag(b *bcsReader) *MoveTypeTag {
+	variant := b.uleb128()
+	if b.err != nil {
+		return nil
+	}
+
+	r := &MoveTypeTag{}
+	switch variant {
+	case 0:
+		r.Bool = newEmptyStruct()
+	case 1:
+		r.Uint8 = newEmptyStruct()
+	case 2:
+		r.Uint64 = newEmptyStruct()
+	case 3:
+		r.Uint128 = newEmptyStruct()
+	case 4:
+		r.Address = newEmptyStruct()
+	case 5:
+		r.Signer = newEmptyStruct()
+	case 6:
+		r.Vector = decodeMoveTypeTag(b)
+	case 7:
+		r.Struct = decodeMoveStructTag(b)
+	case 8:
+		r.Uint16 = newEmptyStruct()
+	case 9:
+		r.Uint32 = newEmptyStruct()
+	case 10:
+		r.Uint256 = newEmptyStruct()
+	default:
+		b.err = fmt.Errorf("unknown move type tag variant: %d", variant)
+	}
+
+	return r
+}
+
+// InstantiateTypeParams returns a copy of the type with the type parameters T0, T1, ... replaced by the type arguments.
+func (m *MoveTypeTag) InstantiateTypeParams(typeArguments []*MoveTypeTag) (*MoveTypeTag, error) {
+	switch {
+	case m.TypeParam != nil:
+		if int(*m.TypeParam) >= len(typeArguments) {
+			return nil, fmt.Errorf("type parameter T%d is out of range, %d type arguments are provided", *m.TypeParam, len(typeArguments))
+		}
+		r := *typeArguments[*m.TypeParam]
+		return &r, nil
+	case m.Vector != nil:
+		element, err := m.Vector.InstantiateTypeParams(typeArguments)
+		if err != nil {
+			return nil, err
+		}
+		return &MoveTypeTag{Vector: element}, nil
+	case m.Struct != nil:
+		r := *m.Struct
+		r.GenericTypeParameters = make([]*MoveTypeTag, 0, len(m.Struct.GenericTypeParameters))
+		for _, param := range m.Struct.GenericTypeParameters {
+			instantiated, err := param.InstantiateTypeParams(typeArguments)
+			if err != nil {
+				return nil, err
+			}
+			r.GenericTypeParameters = append(r.GenericTypeParameters, instantiated)
+		}
+		if len(r.GenericTypeParameters) == 0 {
+			r.GenericTypeParameters = nil
+		}
+		return &MoveTypeTag{Struct: &r}, nil
+	default:
+		r := *m
+		return &r, nil
+	}
+}
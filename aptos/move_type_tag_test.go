@@ -1,9 +1,13 @@
 package aptos_test
 
 import (
+	"bytes"
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -15,6 +19,18 @@ var parseMoveTagTestCases = []struct {
 		typeStr:  "u8",
 		expected: aptos.MoveTypeTag{Uint8: &struct{}{}},
 	},
+	{
+		typeStr:  "u16",
+		expected: aptos.MoveTypeTag{Uint16: &struct{}{}},
+	},
+	{
+		typeStr:  "u32",
+		expected: aptos.MoveTypeTag{Uint32: &struct{}{}},
+	},
+	{
+		typeStr:  "vector<vector<u256>>",
+		expected: aptos.MoveTypeTag{Vector: &aptos.MoveTypeTag{Vector: &aptos.MoveTypeTag{Uint256: &struct{}{}}}},
+	},
 	{
 		typeStr:  "vector<u8>",
 		expected: aptos.MoveTypeTag{Vector: &aptos.MoveTypeTag{Uint8: &struct{}{}}},
@@ -64,3 +80,87 @@ func TestParseMoveTypeTag(t *testing.T) {
 		}
 	}
 }
+
+func TestMoveTypeTag_BCS(t *testing.T) {
+	typeArgs := []*aptos.MoveTypeTag{
+		must(aptos.ParseMoveTypeTag("u16")),
+		must(aptos.ParseMoveTypeTag("u32")),
+		must(aptos.ParseMoveTypeTag("vector<u256>")),
+	}
+	payload := aptos.NewScriptPayload([]byte{0xa1}, typeArgs, []*aptos.ScriptArgument{aptos.ScriptArgument_Uint256(aptos.NewUint256FromUint64(7, 0, 0, 0))})
+
+	encoded := bcs.MustMarshal(payload)
+	want := "00" + "01a1" + "03" + "08" + "09" + "060a" + "01" + "08" + "07" + strings.Repeat("00", 31)
+	if hex.EncodeToString(encoded) != want {
+		t.Fatalf("wrong encoding:\nwant: %s\ngot:  %x", want, encoded)
+	}
+
+	signedTx := &aptos.SignedTransaction{
+		Transaction: &aptos.Transaction{Sender: aptos.AptosStdAddress, Payload: payload},
+		Authenticator: &aptos.TransactionAuthenticator{
+			Ed25519: &aptos.Ed25519Authenticator{PublicKey: make([]byte, 32), Signature: make([]byte, 64)},
+		},
+	}
+	var decoded aptos.SignedTransaction
+	if _, err := bcs.Unmarshal(bcs.MustMarshal(signedTx), &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	script := decoded.Payload.ScriptPayload
+	if !cmp.Equal(script.TypeArguments, typeArgs) || script.Arguments[0].Uint256.String() != "7" {
+		t.Fatalf("wrong decoded script: %v %v", script.TypeArguments, script.Arguments)
+	}
+}
+
+func TestMoveTypeTag_InstantiateTypeParams(t *testing.T) {
+	typeTag := must(aptos.ParseMoveTypeTagWithTypeParams("vector<0x1::simple_map::SimpleMap<T1, 0x1::coin::Coin<T0>>>"))
+	if typeTag.String() != "vector<0x1::simple_map::SimpleMap<T1,0x1::coin::Coin<T0>>>" {
+		t.Fatalf("wrong string: %s", typeTag.String())
+	}
+	if param := typeTag.Vector.Struct.GenericTypeParameters[0].TypeParam; param == nil || *param != 1 {
+		t.Fatalf("T1 is not parsed as type parameter: %v", typeTag.Vector.Struct.GenericTypeParameters[0])
+	}
+
+	instantiated, err := typeTag.InstantiateTypeParams([]*aptos.MoveTypeTag{
+		must(aptos.ParseMoveTypeTag("0x1::aptos_coin::AptosCoin")),
+		must(aptos.ParseMoveTypeTag("address")),
+	})
+	if err != nil {
+		t.Fatalf("failed to instantiate: %v", err)
+	}
+	if want := "vector<0x1::simple_map::SimpleMap<address,0x1::coin::Coin<0x1::aptos_coin::AptosCoin>>>"; instantiated.String() != want {
+		t.Fatalf("want: %s\ngot:  %s", want, instantiated.String())
+	}
+	if typeTag.Vector.Struct.GenericTypeParameters[0].TypeParam == nil {
+		t.Fatalf("original type is modified: %s", typeTag.String())
+	}
+
+	if _, err := typeTag.InstantiateTypeParams([]*aptos.MoveTypeTag{must(aptos.ParseMoveTypeTag("u8"))}); err == nil {
+		t.Fatalf("T1 is instantiated with 1 type argument")
+	}
+}
+
+func TestMoveTypeTag_TypeParamRejected(t *testing.T) {
+	for _, typeStr := range []string{"T0", "vector<T1>", "0x1::coin::Coin<T0>", "0x1::simple_map::SimpleMap<u8, vector<T0>>"} {
+		if _, err := aptos.ParseMoveTypeTag(typeStr); err == nil {
+			t.Errorf("type parameter in %s is accepted", typeStr)
+		}
+		typeTag, err := aptos.ParseMoveTypeTagWithTypeParams(typeStr)
+		if err != nil {
+			t.Fatalf("failed to parse %s with type parameters: %v", typeStr, err)
+		}
+		if _, err := bcs.Marshal(typeTag); err == nil {
+			t.Errorf("%s is marshaled to bcs", typeStr)
+		}
+	}
+
+	if _, err := aptos.ParseMoveStructTag("0x1::coin::Coin<T0>"); err == nil {
+		t.Errorf("type parameter in struct tag is accepted")
+	}
+
+	typeTag := must(aptos.ParseMoveTypeTag("vector<0x1::coin::Coin<u8>>"))
+	want := append([]byte{6, 7}, aptos.AptosStdAddress[:]...)
+	want = append(want, 4, 'c', 'o', 'i', 'n', 4, 'C', 'o', 'i', 'n', 1, 1)
+	if got := must(bcs.Marshal(typeTag)); !bytes.Equal(got, want) {
+		t.Fatalf("want bcs %x, got %x", want, got)
+	}
+}
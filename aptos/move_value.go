@@ -0,0 +1,257 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// MoveValue is a move value decoded from bcs by [MoveValueDecoder], without a go type for the move type.
+// The field corresponding to the kind of Type is set.
+//
+// The json of the MoveValue is the same as the json returned by the node:
+//   - u64, u128, and u256 are decimal strings.
+//   - vector<u8> is a hex string.
+//   - 0x1::string::String is a string.
+//   - structs are objects with the fields in declaration order, including 0x1::option::Option as {"vec": [...]}
+//     and 0x1::object::Object as {"inner": "0x..."}.
+type MoveValue struct {
+	Type *MoveTypeTag
+
+	Bool    *bool
+	Uint8   *uint8
+	Uint16  *uint16
+	Uint32  *uint32
+	Uint64  *uint64
+	Uint128 *bcs.Uint128
+	Uint256 *Uint256
+	Address *Address
+	// Bytes is set for vector<u8>.
+	Bytes  []byte
+	Vector []*MoveValue
+	// Fields are the fields of a struct in declaration order.
+	Fields []*MoveValueField
+}
+
+// MoveValueField is a field of a struct in [MoveValue].
+type MoveValueField struct {
+	Name  string
+	Value *MoveValue
+}
+
+var _ json.Marshaler = (*MoveValue)(nil)
+
+// Field returns the value of the field of a struct, or nil if the field doesn't exist.
+func (v *MoveValue) Field(name string) *MoveValue {
+	for _, field := range v.Fields {
+		if field.Name == name {
+			return field.Value
+		}
+	}
+
+	return nil
+}
+
+func (v *MoveValue) MarshalJSON() ([]byte, error) {
+	t := v.Type
+	switch {
+	case t.Bool != nil:
+		return json.Marshal(*v.Bool)
+	case t.Uint8 != nil:
+		return json.Marshal(*v.Uint8)
+	case t.Uint16 != nil:
+		return json.Marshal(*v.Uint16)
+	case t.Uint32 != nil:
+		return json.Marshal(*v.Uint32)
+	case t.Uint64 != nil:
+		return json.Marshal(JsonUint64(*v.Uint64))
+	case t.Uint128 != nil:
+		return json.Marshal(v.Uint128)
+	case t.Uint256 != nil:
+		return json.Marshal(v.Uint256)
+	case t.Address != nil:
+		return json.Marshal(v.Address)
+	case t.Vector != nil && t.Vector.Uint8 != nil:
+		return json.Marshal(MoveBytecode(v.Bytes))
+	case t.Vector != nil:
+		if v.Vector == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(v.Vector)
+	case t.Struct != nil && isMoveStringStruct(t.Struct):
+		return json.Marshal(string(v.Field("bytes").Bytes))
+	case t.Struct != nil:
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, field := range v.Fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name, _ := json.Marshal(field.Name)
+			buf.Write(name)
+			buf.WriteByte(':')
+			value, err := json.Marshal(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("move value of type %s cannot be marshaled to json", t)
+	}
+}
+
+func isMoveStringStruct(s *MoveStructTag) bool {
+	return s.Address == AptosStdAddress && s.Module == "string" && s.Name == "String" && len(s.GenericTypeParameters) == 0
+}
+
+// builtinStructFields returns the fields of the structs from the standard library that are commonly used,
+// so their abis don't need to be loaded.
+func builtinStructFields(s *MoveStructTag) []*MoveModuleABI_Field {
+	if s.Address != AptosStdAddress {
+		return nil
+	}
+
+	switch {
+	case isMoveStringStruct(s):
+		return []*MoveModuleABI_Field{{Name: "bytes", Type: &MoveTypeTag{Vector: &MoveTypeTag{Uint8: &struct{}{}}}}}
+	case s.Module == "option" && s.Name == "Option" && len(s.GenericTypeParameters) == 1:
+		return []*MoveModuleABI_Field{{Name: "vec", Type: &MoveTypeTag{Vector: s.GenericTypeParameters[0]}}}
+	case s.Module == "object" && s.Name == "Object" && len(s.GenericTypeParameters) == 1:
+		return []*MoveModuleABI_Field{{Name: "inner", Type: &MoveTypeTag{Address: &struct{}{}}}}
+	default:
+		return nil
+	}
+}
+
+// MoveValueDecoder decodes bcs into [MoveValue] by the layouts of the structs, which are resolved from the abis of the modules.
+// 0x1::string::String, 0x1::option::Option, and 0x1::object::Object are decoded without their abis.
+type MoveValueDecoder struct {
+	ABIs *ModuleABICache
+}
+
+// NewMoveValueDecoder creates a new [MoveValueDecoder] loading the abis with the client.
+func NewMoveValueDecoder(client *Client) *MoveValueDecoder {
+	return &MoveValueDecoder{ABIs: NewModuleABICache(client)}
+}
+
+// Decode decodes the bcs data of the type. All the data must be consumed.
+func (d *MoveValueDecoder) Decode(ctx context.Context, typeTag *MoveTypeTag, data []byte) (*MoveValue, error) {
+	b := newBcsReader(bytes.NewReader(data))
+
+	v, err := d.decode(ctx, b, typeTag)
+	if err != nil {
+		return nil, err
+	}
+	if b.err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", typeTag, b.err)
+	}
+	if b.n != len(data) {
+		return nil, fmt.Errorf("%d bytes left after decoding %s", len(data)-b.n, typeTag)
+	}
+
+	return v, nil
+}
+
+// DecodeResource decodes the resource returned by [Client.GetAccountResource] in bcs response mode.
+func (d *MoveValueDecoder) DecodeResource(ctx context.Context, resource *GetAccountResourceResponse) (*MoveValue, error) {
+	if resource.AccountResource == nil || resource.Type == nil || resource.BcsData == nil {
+		return nil, fmt.Errorf("resource is not in bcs")
+	}
+
+	return d.Decode(ctx, &MoveTypeTag{Struct: resource.Type}, resource.BcsData)
+}
+
+func (d *MoveValueDecoder) decode(ctx context.Context, b *bcsReader, t *MoveTypeTag) (*MoveValue, error) {
+	v := &MoveValue{Type: t}
+	switch {
+	case t.Bool != nil:
+		v.Bool = new(bool)
+		b.decode(v.Bool)
+	case t.Uint8 != nil:
+		v.Uint8 = new(uint8)
+		b.decode(v.Uint8)
+	case t.Uint16 != nil:
+		v.Uint16 = new(uint16)
+		b.decode(v.Uint16)
+	case t.Uint32 != nil:
+		v.Uint32 = new(uint32)
+		b.decode(v.Uint32)
+	case t.Uint64 != nil:
+		v.Uint64 = new(uint64)
+		b.decode(v.Uint64)
+	case t.Uint128 != nil:
+		v.Uint128 = new(bcs.Uint128)
+		b.decode(v.Uint128)
+	case t.Uint256 != nil:
+		v.Uint256 = new(Uint256)
+		b.decode(v.Uint256)
+	case t.Address != nil:
+		v.Address = new(Address)
+		b.decode(v.Address)
+	case t.Vector != nil && t.Vector.Uint8 != nil:
+		v.Bytes = b.bytes()
+	case t.Vector != nil:
+		n := b.uleb128()
+		// the length is not trusted for allocation, each element takes at least one byte.
+		for i := 0; i < n && b.err == nil; i++ {
+			element, err := d.decode(ctx, b, t.Vector)
+			if err != nil {
+				return nil, err
+			}
+			v.Vector = append(v.Vector, element)
+		}
+	case t.Struct != nil:
+		fields, err := d.structFields(ctx, t.Struct)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range fields {
+			value, err := d.decode(ctx, b, field.Type)
+			if err != nil {
+				return nil, err
+			}
+			v.Fields = append(v.Fields, &MoveValueField{Name: field.Name, Value: value})
+		}
+	default:
+		return nil, fmt.Errorf("type %s cannot be decoded", t)
+	}
+
+	if b.err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", t, b.err)
+	}
+
+	return v, nil
+}
+
+// structFields returns the fields of the struct with the type parameters instantiated.
+func (d *MoveValueDecoder) structFields(ctx context.Context, tag *MoveStructTag) ([]*MoveModuleABI_Field, error) {
+	if fields := builtinStructFields(tag); fields != nil {
+		return fields, nil
+	}
+
+	s, err := d.ABIs.GetStruct(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsNative {
+		return nil, fmt.Errorf("native struct %s cannot be decoded", tag)
+	}
+
+	fieldTypes, err := s.FieldTypes(tag.GenericTypeParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]*MoveModuleABI_Field, 0, len(s.Fields))
+	for i, field := range s.Fields {
+		r = append(r, &MoveModuleABI_Field{Name: field.Name, Type: fieldTypes[i]})
+	}
+
+	return r, nil
+}
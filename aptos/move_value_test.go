@@ -0,0 +1,196 @@
+package aptos_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+	"github.com/google/go-cmp/cmp"
+)
+
+const testPositionABI = `{
+  "address": "0xcafe",
+  "name": "position",
+  "friends": [],
+  "exposed_functions": [],
+  "structs": [
+    {
+      "name": "Position",
+      "is_native": false,
+      "abilities": ["key"],
+      "generic_type_params": [{"constraints": []}, {"constraints": []}],
+      "fields": [
+        {"name": "owner", "type": "address"},
+        {"name": "shares", "type": "u64"},
+        {"name": "total", "type": "u128"},
+        {"name": "max", "type": "u256"},
+        {"name": "tier", "type": "u8"},
+        {"name": "rate", "type": "u16"},
+        {"name": "epoch", "type": "u32"},
+        {"name": "flags", "type": "vector<bool>"},
+        {"name": "memo", "type": "vector<u8>"},
+        {"name": "name", "type": "0x1::string::String"},
+        {"name": "metadata", "type": "0x1::option::Option<0x1::object::Object<0x1::fungible_asset::Metadata>>"},
+        {"name": "ticks", "type": "vector<0xcafe::position::Tick<T1>>"}
+      ]
+    },
+    {
+      "name": "Tick",
+      "is_native": false,
+      "abilities": ["copy", "drop", "store"],
+      "generic_type_params": [{"constraints": []}],
+      "fields": [
+        {"name": "index", "type": "u32"},
+        {"name": "value", "type": "T0"}
+      ]
+    }
+  ]
+}`
+
+type testTick struct {
+	Index uint32
+	Value uint64
+}
+
+type testPosition struct {
+	Owner    aptos.Address
+	Shares   uint64
+	Total    bcs.Uint128
+	Max      aptos.Uint256
+	Tier     uint8
+	Rate     uint16
+	Epoch    uint32
+	Flags    []bool
+	Memo     []byte
+	Name     string
+	Metadata []aptos.Address
+	Ticks    []testTick
+}
+
+var testPositionType = must(aptos.ParseMoveStructTag("0xcafe::position::Position<0x1::aptos_coin::AptosCoin, u64>"))
+
+func newTestPositionBcs() []byte {
+	return bcs.MustMarshal(&testPosition{
+		Owner:    aptos.MustParseAddress("0x2"),
+		Shares:   18446744073709551615,
+		Total:    *bcs.NewUint128FromUint64(0, 1),
+		Max:      *aptos.NewUint256FromUint64(0, 0, 0, 1),
+		Tier:     3,
+		Rate:     500,
+		Epoch:    70000,
+		Flags:    []bool{true, false},
+		Memo:     []byte{0xab, 0xcd},
+		Name:     "pool",
+		Metadata: []aptos.Address{aptos.MustParseAddress("0xa")},
+		Ticks:    []testTick{{Index: 1, Value: 10}},
+	})
+}
+
+const testPositionJSON = `{
+  "owner": "0x2",
+  "shares": "18446744073709551615",
+  "total": "18446744073709551616",
+  "max": "6277101735386680763835789423207666416102355444464034512896",
+  "tier": 3,
+  "rate": 500,
+  "epoch": 70000,
+  "flags": [true, false],
+  "memo": "0xabcd",
+  "name": "pool",
+  "metadata": {"vec": [{"inner": "0xa"}]},
+  "ticks": [{"index": 1, "value": "10"}]
+}`
+
+func newTestMoveValueDecoder(t *testing.T) *aptos.MoveValueDecoder {
+	decoder := aptos.NewMoveValueDecoder(nil)
+	abi := &aptos.MoveModuleABI{}
+	if err := json.Unmarshal([]byte(testPositionABI), abi); err != nil {
+		t.Fatalf("failed to parse abi: %v", err)
+	}
+	decoder.ABIs.Add(abi)
+
+	return decoder
+}
+
+// jsonEqual compares the json ignoring the formatting.
+func jsonEqual(t *testing.T, want string, got []byte) {
+	t.Helper()
+
+	var wantValue, gotValue any
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("failed to parse json: %v", err)
+	}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("failed to parse json: %v", err)
+	}
+	if !cmp.Equal(wantValue, gotValue) {
+		t.Fatalf("want: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestMoveValueDecoder_Decode(t *testing.T) {
+	decoder := newTestMoveValueDecoder(t)
+	ctx := context.Background()
+
+	value, err := decoder.Decode(ctx, &aptos.MoveTypeTag{Struct: testPositionType}, newTestPositionBcs())
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if *value.Field("shares").Uint64 != 18446744073709551615 || value.Field("ticks").Vector[0].Field("value").Type.Uint64 == nil {
+		t.Fatalf("wrong value: %#v", value)
+	}
+
+	got, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	jsonEqual(t, testPositionJSON, got)
+
+	// fields are in declaration order.
+	if want := `{"index":1,"value":"10"}`; string(must(json.Marshal(value.Field("ticks").Vector[0]))) != want {
+		t.Fatalf("want %s, got %s", want, must(json.Marshal(value.Field("ticks").Vector[0])))
+	}
+
+	if _, err := decoder.Decode(ctx, &aptos.MoveTypeTag{Struct: testPositionType}, append(newTestPositionBcs(), 0)); err == nil {
+		t.Fatalf("trailing bytes are not rejected")
+	}
+	if _, err := decoder.Decode(ctx, &aptos.MoveTypeTag{Struct: testPositionType}, newTestPositionBcs()[:40]); err == nil {
+		t.Fatalf("truncated data is not rejected")
+	}
+	if _, err := decoder.Decode(ctx, must(aptos.ParseMoveTypeTag("0xcafe::position::Missing")), []byte{}); err == nil {
+		t.Fatalf("unknown struct is decoded")
+	}
+
+	empty, err := decoder.Decode(ctx, must(aptos.ParseMoveTypeTag("0x1::option::Option<vector<u64>>")), []byte{0})
+	if err != nil {
+		t.Fatalf("failed to decode option: %v", err)
+	}
+	jsonEqual(t, `{"vec": []}`, must(json.Marshal(empty)))
+}
+
+func TestMoveValueDecoder_DecodeResource(t *testing.T) {
+	data := newTestPositionBcs()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", aptos.ContentTypeBcs)
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := aptos.ApplyClientOptions(aptos.MustNewClient(aptos.Localnet, server.URL), aptos.ClientOption_BcsResponse(true))
+	ctx := context.Background()
+
+	resource, err := client.GetAccountResource(ctx, &aptos.GetAccountResourceRequest{Address: aptos.MustParseAddress("0x2"), Type: testPositionType})
+	if err != nil {
+		t.Fatalf("failed to get resource: %v", err)
+	}
+
+	value, err := newTestMoveValueDecoder(t).DecodeResource(ctx, resource.Parsed)
+	if err != nil {
+		t.Fatalf("failed to decode resource: %v", err)
+	}
+	jsonEqual(t, testPositionJSON, must(json.Marshal(value)))
+}
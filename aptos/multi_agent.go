@@ -0,0 +1,208 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// rawTransactionWithDataPrefix is sha3-256 of "APTOS::RawTransactionWithData"
+var rawTransactionWithDataPrefix []byte = sha3_Sum256Slice([]byte("APTOS::RawTransactionWithData"))
+
+// variants of RawTransactionWithData in aptos-core, which is signed by the signers of multi agent and fee payer transactions.
+const (
+	rawTransactionWithDataVariant_MultiAgent = 0
+	rawTransactionWithDataVariant_FeePayer   = 1
+)
+
+// MultiAgentTransaction is a transaction that requires signatures from the sender and the secondary signers.
+// The entry function receives the signers in the order of sender followed by the secondary signers.
+type MultiAgentTransaction struct {
+	*Transaction
+	SecondarySigners []Address
+}
+
+// NewMultiAgentTransaction creates a multi agent transaction from the transaction and the addresses of the secondary signers.
+func NewMultiAgentTransaction(tx *Transaction, secondarySigners ...Address) *MultiAgentTransaction {
+	return &MultiAgentTransaction{
+		Transaction:      tx,
+		SecondarySigners: secondarySigners,
+	}
+}
+
+// EncodeMultiAgentTransaction for signing.
+//
+// The signing message is sha3-256 of "APTOS::RawTransactionWithData", followed by bcs encoded RawTransactionWithData::MultiAgent:
+//
+//   - 0x00, the variant of MultiAgent
+//   - bcs encoded raw transaction
+//   - secondary signer addresses
+//
+// Sender and secondary signers sign the same message.
+func EncodeMultiAgentTransaction(tx *MultiAgentTransaction) ([]byte, error) {
+	txBytes, err := bcs.Marshal(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	addressBytes, err := bcs.Marshal(tx.SecondarySigners)
+	if err != nil {
+		return nil, err
+	}
+
+	r := append([]byte{}, rawTransactionWithDataPrefix...)
+	r = append(r, rawTransactionWithDataVariant_MultiAgent)
+	r = append(r, txBytes...)
+	r = append(r, addressBytes...)
+
+	return r, nil
+}
+
+// MultiAgentAuthenticator contains the authenticators of the sender and the secondary signers of a [MultiAgentTransaction].
+type MultiAgentAuthenticator struct {
+	Sender                   *AccountAuthenticator
+	SecondarySignerAddresses []Address
+	SecondarySigners         []*AccountAuthenticator
+}
+
+func (a *MultiAgentAuthenticator) decode(b *bcsReader) {
+	a.Sender = &AccountAuthenticator{}
+	a.Sender.decode(b)
+
+	addressCount := b.uleb128()
+	a.SecondarySignerAddresses = make([]Address, addressCount)
+	for i := 0; i < addressCount && b.err == nil; i++ {
+		b.decode(&a.SecondarySignerAddresses[i])
+	}
+
+	signerCount := b.uleb128()
+	for i := 0; i < signerCount && b.err == nil; i++ {
+		signer := &AccountAuthenticator{}
+		signer.decode(b)
+		a.SecondarySigners = append(a.SecondarySigners, signer)
+	}
+}
+
+// MultiAgentSigner is a [Signer] that can also sign raw data, which is required to sign a [MultiAgentTransaction]
+// or a [FeePayerTransaction]. [LocalAccount] implements this interface.
+type MultiAgentSigner interface {
+	Signer
+	RawDataSigner
+}
+
+// rawDataSignFunc is either [RawDataSigner.SignRawData] or [RawDataSigner.SignRawDataForSimulation].
+type rawDataSignFunc func(RawDataSigner, []byte) (*SingleSignature, error)
+
+// signRawTransactionWithData checks the addresses of the signers, and collects the signatures of the sender and secondary signers on the message.
+func signRawTransactionWithData(
+	tx *Transaction,
+	secondarySignerAddresses []Address,
+	message []byte,
+	sign rawDataSignFunc,
+	sender MultiAgentSigner,
+	secondarySigners []MultiAgentSigner,
+) (*AccountAuthenticator, []*AccountAuthenticator, error) {
+	if sender.SignerAddress() != tx.Sender {
+		return nil, nil, fmt.Errorf("signer %s is not the sender %s", sender.SignerAddress(), tx.Sender)
+	}
+	if len(secondarySigners) != len(secondarySignerAddresses) {
+		return nil, nil, fmt.Errorf("transaction requires %d secondary signers, but %d are provided", len(secondarySignerAddresses), len(secondarySigners))
+	}
+	for i, signer := range secondarySigners {
+		if signer.SignerAddress() != secondarySignerAddresses[i] {
+			return nil, nil, fmt.Errorf("signer %s is not the secondary signer %s at %d", signer.SignerAddress(), secondarySignerAddresses[i], i)
+		}
+	}
+
+	senderAuthenticator, err := signForAccountAuthenticator(message, sign, sender)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign for sender: %w", err)
+	}
+
+	var secondaryAuthenticators []*AccountAuthenticator
+	for i, signer := range secondarySigners {
+		secondaryAuthenticator, err := signForAccountAuthenticator(message, sign, signer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign for secondary signer %s: %w", secondarySignerAddresses[i], err)
+		}
+		secondaryAuthenticators = append(secondaryAuthenticators, secondaryAuthenticator)
+	}
+
+	return senderAuthenticator, secondaryAuthenticators, nil
+}
+
+func signForAccountAuthenticator(message []byte, sign rawDataSignFunc, signer RawDataSigner) (*AccountAuthenticator, error) {
+	signature, err := sign(signer, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountAuthenticator(signature)
+}
+
+// signMultiAgentTransaction collects the signatures of the sender and secondary signers, and creates the [SignedTransaction].
+func signMultiAgentTransaction(tx *MultiAgentTransaction, sign rawDataSignFunc, sender MultiAgentSigner, secondarySigners []MultiAgentSigner) (*SignedTransaction, error) {
+	message, err := EncodeMultiAgentTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	senderAuthenticator, secondaryAuthenticators, err := signRawTransactionWithData(tx.Transaction, tx.SecondarySigners, message, sign, sender, secondarySigners)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransaction{
+		Transaction: tx.Transaction,
+		Authenticator: &TransactionAuthenticator{
+			MultiAgent: &MultiAgentAuthenticator{
+				Sender:                   senderAuthenticator,
+				SecondarySignerAddresses: tx.SecondarySigners,
+				SecondarySigners:         secondaryAuthenticators,
+			},
+		},
+	}, nil
+}
+
+// SignMultiAgentTransaction signs the multi agent transaction by the sender and the secondary signers.
+// The secondary signers must be in the same order as [MultiAgentTransaction].SecondarySigners, and the addresses of the signers are checked.
+func SignMultiAgentTransaction(tx *MultiAgentTransaction, sender MultiAgentSigner, secondarySigners ...MultiAgentSigner) (*SignedTransaction, error) {
+	return signMultiAgentTransaction(tx, RawDataSigner.SignRawData, sender, secondarySigners)
+}
+
+// SignMultiAgentTransactionForSimulation creates the [SignedTransaction] for [Client.SimulateSignedTransactionBCS] with invalid signatures.
+func SignMultiAgentTransactionForSimulation(tx *MultiAgentTransaction, sender MultiAgentSigner, secondarySigners ...MultiAgentSigner) (*SignedTransaction, error) {
+	return signMultiAgentTransaction(tx, RawDataSigner.SignRawDataForSimulation, sender, secondarySigners)
+}
+
+// SignSubmitMultiAgentTransactionWait is a convenient function to sign a multi agent transaction by all the signers,
+// submit it in bcs, and optionally wait for it.
+func (client *Client) SignSubmitMultiAgentTransactionWait(
+	ctx context.Context,
+	tx *MultiAgentTransaction,
+	sender MultiAgentSigner,
+	secondarySigners []MultiAgentSigner,
+	noWait bool,
+	waitOptions ...TransactionWaitOption,
+) (*TransactionWithInfo, error) {
+	signedTx, err := SignMultiAgentTransaction(tx, sender, secondarySigners...)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.submitSignedTransactionBCSWait(ctx, signedTx, noWait, waitOptions...)
+}
+
+// submitSignedTransactionBCSWait submits the signed transaction in bcs, and waits for it unless noWait is true.
+func (client *Client) submitSignedTransactionBCSWait(ctx context.Context, signedTx *SignedTransaction, noWait bool, waitOptions ...TransactionWaitOption) (*TransactionWithInfo, error) {
+	resp, err := client.SubmitSignedTransactionBCS(ctx, signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if noWait {
+		return resp.Parsed.TransactionWithInfo, nil
+	}
+
+	return client.WaitForTransaction(ctx, resp.Parsed.Hash, waitOptions...)
+}
@@ -0,0 +1,132 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func testMultiAgentTransaction(t *testing.T) (*aptos.MultiAgentTransaction, *aptos.LocalAccount, *aptos.LocalAccount) {
+	t.Helper()
+
+	sender, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondary, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &aptos.Transaction{
+		Sender: sender.Address,
+		Payload: aptos.NewEntryFunctionPayload(
+			aptos.MustNewMoveFunctionTag(sender.Address, "escrow", "swap"),
+			nil,
+			[]*aptos.EntryFunctionArg{aptos.EntryFunctionArg_Uint64(100)},
+		),
+	}
+	aptos.ApplyTransactionOptions(
+		tx,
+		aptos.TransactionOption_SequenceNumber(3),
+		aptos.TransactionOption_MaxGasAmount(2000),
+		aptos.TransactionOption_GasUnitPrice(100),
+	)
+	tx.ExpirationTimestampSecs = 1667245112
+	tx.ChainId = 35
+
+	return aptos.NewMultiAgentTransaction(tx, secondary.Address), sender, secondary
+}
+
+func TestSignMultiAgentTransaction(t *testing.T) {
+	tx, sender, secondary := testMultiAgentTransaction(t)
+
+	signedTx, err := aptos.SignMultiAgentTransaction(tx, sender, secondary)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	message, err := aptos.EncodeMultiAgentTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := signedTx.Authenticator.MultiAgent
+	if auth == nil || len(auth.SecondarySigners) != 1 || auth.SecondarySignerAddresses[0] != secondary.Address {
+		t.Fatalf("wrong authenticator: %#v", signedTx.Authenticator)
+	}
+	for i, a := range append([]*aptos.AccountAuthenticator{auth.Sender}, auth.SecondarySigners...) {
+		if !ed25519.Verify(a.Ed25519.PublicKey, message, a.Ed25519.Signature) {
+			t.Errorf("signature %d cannot be verified", i)
+		}
+	}
+
+	encoded, err := bcs.Marshal(signedTx)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	var decoded aptos.SignedTransaction
+	if _, err := bcs.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if decoded.Authenticator.MultiAgent == nil || decoded.Authenticator.MultiAgent.SecondarySignerAddresses[0] != secondary.Address {
+		t.Fatalf("wrong authenticator decoded: %#v", decoded.Authenticator)
+	}
+	if reEncoded := bcs.MustMarshal(&decoded); !bytes.Equal(encoded, reEncoded) {
+		t.Fatalf("round trip changes the encoding:\nwant: %x\ngot:  %x", encoded, reEncoded)
+	}
+
+	if _, err := aptos.SignMultiAgentTransaction(tx, secondary, sender); err == nil {
+		t.Fatalf("signers in the wrong order should fail")
+	}
+	if _, err := aptos.SignMultiAgentTransaction(tx, sender); err == nil {
+		t.Fatalf("missing secondary signer should fail")
+	}
+}
+
+func TestClient_SignSubmitMultiAgentTransactionWait(t *testing.T) {
+	tx, sender, secondary := testMultiAgentTransaction(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var signedTx aptos.SignedTransaction
+		if _, err := bcs.Unmarshal(body, &signedTx); err != nil {
+			t.Errorf("failed to decode submitted transaction: %v", err)
+		}
+		if signedTx.Authenticator.MultiAgent == nil {
+			t.Errorf("submitted transaction is not multi agent")
+		}
+		if r.URL.Path == "/transactions/simulate" {
+			w.Write([]byte(`[{"type": "user_transaction", "hash": "0x01", "success": true}]`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"type": "pending_transaction", "hash": "0x01"}`))
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+
+	simulationTx, err := aptos.SignMultiAgentTransactionForSimulation(tx, sender, secondary)
+	if err != nil {
+		t.Fatalf("failed to sign for simulation: %v", err)
+	}
+	if _, err := client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{SignedTransaction: simulationTx}); err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+
+	txInfo, err := client.SignSubmitMultiAgentTransactionWait(context.Background(), tx, sender, []aptos.MultiAgentSigner{secondary}, true)
+	if err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+	if txInfo.Hash != "0x01" {
+		t.Fatalf("wrong hash: %s", txInfo.Hash)
+	}
+}
@@ -0,0 +1,281 @@
+package aptos
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// MultiEd25519MaxSignerCount is the max number of public keys of a multi ed25519 account.
+const MultiEd25519MaxSignerCount = 32
+
+// multiEd25519BitmapLength is the length of the bitmap in bytes.
+const multiEd25519BitmapLength = 4
+
+// MultiEd25519Authenticator contains the public keys and the signatures of a K-of-N multi ed25519 signer.
+//   - PublicKey is the concatenated public keys followed by the threshold K.
+//   - Signature is the concatenated signatures ordered by the index of the public keys, followed by a 4 byte bitmap.
+//     The i-th bit (counting from the most significant bit of the first byte) is set if the i-th public key signs.
+type MultiEd25519Authenticator struct {
+	PublicKey []byte
+	Signature []byte
+}
+
+// isSimulation checks if all the signatures are zero, as required by simulation.
+func (a *MultiEd25519Authenticator) isSimulation() bool {
+	if len(a.Signature) < multiEd25519BitmapLength {
+		return false
+	}
+	for _, v := range a.Signature[:len(a.Signature)-multiEd25519BitmapLength] {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// verify checks that the signatures are from at least threshold public keys.
+func (a *MultiEd25519Authenticator) verify(message []byte) error {
+	if len(a.PublicKey)%ed25519.PublicKeySize != 1 || len(a.Signature) < multiEd25519BitmapLength {
+		return fmt.Errorf("malformed multi ed25519 authenticator")
+	}
+	keyCount := len(a.PublicKey) / ed25519.PublicKeySize
+	threshold := int(a.PublicKey[len(a.PublicKey)-1])
+
+	signatures := a.Signature[:len(a.Signature)-multiEd25519BitmapLength]
+	indices := decodeSignerBitmap(a.Signature[len(signatures):])
+	if len(signatures) != len(indices)*SignatureLength {
+		return fmt.Errorf("bitmap has %d signers, but signatures are %d bytes", len(indices), len(signatures))
+	}
+	if threshold == 0 || len(indices) < threshold {
+		return fmt.Errorf("%d signatures are provided, but %d are required", len(indices), threshold)
+	}
+
+	for i, index := range indices {
+		if index >= keyCount {
+			return fmt.Errorf("signer %d is out of %d public keys", index, keyCount)
+		}
+		publicKey := a.PublicKey[index*ed25519.PublicKeySize : (index+1)*ed25519.PublicKeySize]
+		if !ed25519.Verify(publicKey, message, signatures[i*SignatureLength:(i+1)*SignatureLength]) {
+			return fmt.Errorf("invalid signature of signer %d", index)
+		}
+	}
+
+	return nil
+}
+
+// newMultiEd25519Authenticator parses the public keys, signatures, and bitmap of a multi ed25519 signature.
+func newMultiEd25519Authenticator(signature *SingleSignature) (*MultiEd25519Authenticator, error) {
+	if signature.Type != MultiEd25519SignatureType {
+		return nil, fmt.Errorf("unsupported signature type: %s", signature.Type)
+	}
+
+	if len(signature.PublicKeys) == 0 || len(signature.PublicKeys) > MultiEd25519MaxSignerCount {
+		return nil, fmt.Errorf("public key count %d is not between 1 and %d", len(signature.PublicKeys), MultiEd25519MaxSignerCount)
+	}
+	if signature.Threshold == 0 || int(signature.Threshold) > len(signature.PublicKeys) {
+		return nil, fmt.Errorf("threshold %d is not between 1 and %d", signature.Threshold, len(signature.PublicKeys))
+	}
+
+	r := &MultiEd25519Authenticator{}
+
+	for _, publicKeyStr := range signature.PublicKeys {
+		publicKey, err := parseHexString(publicKeyStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %s: %w", publicKeyStr, err)
+		}
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("public key %s is not length %d", publicKeyStr, ed25519.PublicKeySize)
+		}
+		r.PublicKey = append(r.PublicKey, publicKey...)
+	}
+	r.PublicKey = append(r.PublicKey, signature.Threshold)
+
+	for _, sigStr := range signature.Signatures {
+		sig, err := parseHexString(sigStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signature %s: %w", sigStr, err)
+		}
+		if len(sig) != SignatureLength {
+			return nil, fmt.Errorf("signature %s is not length %d", sigStr, SignatureLength)
+		}
+		r.Signature = append(r.Signature, sig...)
+	}
+
+	bitmap, err := parseHexString(signature.Bitmap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bitmap %s: %w", signature.Bitmap, err)
+	}
+	if len(bitmap) != multiEd25519BitmapLength {
+		return nil, fmt.Errorf("bitmap %s is not length %d", signature.Bitmap, multiEd25519BitmapLength)
+	}
+	indices := decodeSignerBitmap(bitmap)
+	if len(indices) != len(signature.Signatures) {
+		return nil, fmt.Errorf("bitmap %s has %d signers, but %d signatures are provided", signature.Bitmap, len(indices), len(signature.Signatures))
+	}
+	if len(indices) > 0 && indices[len(indices)-1] >= len(signature.PublicKeys) {
+		return nil, fmt.Errorf("bitmap %s contains signer %d, but there are only %d public keys", signature.Bitmap, indices[len(indices)-1], len(signature.PublicKeys))
+	}
+	r.Signature = append(r.Signature, bitmap...)
+
+	return r, nil
+}
+
+// encodeSignerBitmap sets the bits of the signer indices in a 4 byte bitmap, the indices must be less than 32.
+func encodeSignerBitmap(indices []int) []byte {
+	bitmap := make([]byte, multiEd25519BitmapLength)
+	for _, i := range indices {
+		bitmap[i/8] |= 0x80 >> (i % 8)
+	}
+
+	return bitmap
+}
+
+// decodeSignerBitmap returns the indices of the set bits in ascending order.
+func decodeSignerBitmap(bitmap []byte) []int {
+	var indices []int
+	for i := 0; i < len(bitmap)*8; i++ {
+		if bitmap[i/8]&(0x80>>(i%8)) != 0 {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// MultiEd25519Account is a K-of-N account, which requires signatures from K of its N ed25519 public keys.
+//
+// The signers of the public keys are added by [MultiEd25519Account.AddSigner]. When signing, the account collects the partial signatures
+// from the first K signers ordered by the index of their public keys, and encodes them into a [MultiEd25519SignatureType] signature.
+// Signers for the remaining keys are not necessary.
+//
+// For simulation, signers are not required and the signatures of the first K public keys are all zero.
+type MultiEd25519Account struct {
+	PublicKeys []ed25519.PublicKey
+	Threshold  uint8
+	Address    Address
+
+	// signers has the same length as PublicKeys, and is nil if the signer of the public key is not added.
+	signers []RawDataSigner
+}
+
+var (
+	_ Signer        = (*MultiEd25519Account)(nil)
+	_ RawDataSigner = (*MultiEd25519Account)(nil)
+)
+
+// NewMultiEd25519Account creates a K-of-N account from the public keys, where K is the threshold.
+// The address is the authentication key calculated from the public keys and threshold, see [GenerateAuthenticationKey].
+// The order of the public keys matters.
+func NewMultiEd25519Account(threshold int, publicKeys ...ed25519.PublicKey) (*MultiEd25519Account, error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MultiEd25519MaxSignerCount {
+		return nil, fmt.Errorf("public key count %d is not between 1 and %d", len(publicKeys), MultiEd25519MaxSignerCount)
+	}
+	if threshold <= 0 || threshold > len(publicKeys) {
+		return nil, fmt.Errorf("threshold %d is not between 1 and %d", threshold, len(publicKeys))
+	}
+
+	var allBytes []byte
+	for _, publicKey := range publicKeys {
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("public key %s is not length %d", prefixedHexString(publicKey), ed25519.PublicKeySize)
+		}
+		allBytes = append(allBytes, publicKey...)
+	}
+	allBytes = append(allBytes, byte(threshold), 1)
+
+	return &MultiEd25519Account{
+		PublicKeys: publicKeys,
+		Threshold:  uint8(threshold),
+		Address:    sha3.Sum256(allBytes),
+		signers:    make([]RawDataSigner, len(publicKeys)),
+	}, nil
+}
+
+// AddSigner adds the signer for the public key, which must be one of the public keys of the account.
+func (account *MultiEd25519Account) AddSigner(publicKey ed25519.PublicKey, signer RawDataSigner) error {
+	for i, key := range account.PublicKeys {
+		if bytes.Equal(key, publicKey) {
+			account.signers[i] = signer
+			return nil
+		}
+	}
+
+	return fmt.Errorf("public key %s is not part of the account %s", prefixedHexString(publicKey), account.Address)
+}
+
+func (account *MultiEd25519Account) Sign(tx *Transaction) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &account.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	return account.SignRawData(EncodeTransaction(tx))
+}
+
+func (account *MultiEd25519Account) SignForSimulation(tx *Transaction) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &account.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	return account.SignRawDataForSimulation(EncodeTransaction(tx))
+}
+
+func (account *MultiEd25519Account) SignerAddress() Address {
+	return account.Address
+}
+
+// SignRawData collects the signatures from the first K signers, and returns a multi ed25519 signature.
+func (account *MultiEd25519Account) SignRawData(message []byte) (*SingleSignature, error) {
+	var indices []int
+	var signatures []string
+	for i, signer := range account.signers {
+		if len(indices) >= int(account.Threshold) {
+			break
+		}
+		if signer == nil {
+			continue
+		}
+
+		signature, err := signer.SignRawData(message)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d failed to sign: %w", i, err)
+		}
+		if signature.Type != Ed25519SignatureType || signature.PublicKey != prefixedHexString(account.PublicKeys[i]) {
+			return nil, fmt.Errorf("signer %d returns signature of %s, want ed25519 public key %s", i, signature.PublicKey, prefixedHexString(account.PublicKeys[i]))
+		}
+
+		indices = append(indices, i)
+		signatures = append(signatures, signature.Signature)
+	}
+
+	if len(indices) < int(account.Threshold) {
+		return nil, fmt.Errorf("requires %d signers, but only %d are added", account.Threshold, len(indices))
+	}
+
+	return account.newSingleSignature(indices, signatures), nil
+}
+
+// SignRawDataForSimulation returns all zero signatures of the first K public keys.
+func (account *MultiEd25519Account) SignRawDataForSimulation(message []byte) (*SingleSignature, error) {
+	var indices []int
+	var signatures []string
+	for i := 0; i < int(account.Threshold); i++ {
+		indices = append(indices, i)
+		signatures = append(signatures, simulationSignature)
+	}
+
+	return account.newSingleSignature(indices, signatures), nil
+}
+
+func (account *MultiEd25519Account) newSingleSignature(indices []int, signatures []string) *SingleSignature {
+	return &SingleSignature{
+		Type:       MultiEd25519SignatureType,
+		PublicKeys: mapSlices(account.PublicKeys, func(key ed25519.PublicKey) string { return prefixedHexString(key) }),
+		Signatures: signatures,
+		Threshold:  account.Threshold,
+		Bitmap:     prefixedHexString(encodeSignerBitmap(indices)),
+	}
+}
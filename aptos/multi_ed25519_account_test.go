@@ -0,0 +1,93 @@
+package aptos_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func TestMultiEd25519Account(t *testing.T) {
+	var keys []*aptos.LocalAccount
+	for i := 0; i < 3; i++ {
+		key, err := aptos.NewLocalAccountWithRandomKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+
+	account, err := aptos.NewMultiEd25519Account(2, keys[0].PublicKey, keys[1].PublicKey, keys[2].PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	authKey, _ := aptos.GenerateAuthenticationKey(3, 2, keys[0].PublicKey, keys[1].PublicKey, keys[2].PublicKey)
+	if account.Address != authKey {
+		t.Fatalf("want address %s, got %s", authKey, account.Address)
+	}
+
+	tx, _, _ := testMultiAgentTransaction(t)
+	tx.Sender = account.Address
+
+	if _, err := account.Sign(tx.Transaction); err == nil {
+		t.Fatalf("signing without enough signers should fail")
+	}
+
+	for _, key := range keys[1:] {
+		if err := account.AddSigner(key.PublicKey, key); err != nil {
+			t.Fatalf("failed to add signer: %v", err)
+		}
+	}
+	if err := account.AddSigner(tx.SecondarySigners[0][:], keys[0]); err == nil {
+		t.Fatalf("adding unknown public key should fail")
+	}
+
+	signature, err := account.Sign(tx.Transaction)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if signature.Bitmap != "0x60000000" || len(signature.Signatures) != 2 {
+		t.Fatalf("wrong signature: %#v", signature)
+	}
+	if _, err := json.Marshal(signature); err != nil {
+		t.Fatalf("failed to marshal signature to json: %v", err)
+	}
+
+	signedTx, err := aptos.NewSignedTransaction(tx.Transaction, signature)
+	if err != nil {
+		t.Fatalf("failed to create signed transaction: %v", err)
+	}
+	auth := signedTx.Authenticator.MultiEd25519
+	if auth == nil || len(auth.PublicKey) != 3*ed25519.PublicKeySize+1 || len(auth.Signature) != 2*ed25519.SignatureSize+4 {
+		t.Fatalf("wrong authenticator: %#v", signedTx.Authenticator)
+	}
+	message := aptos.EncodeTransaction(tx.Transaction)
+	for i, key := range keys[1:] {
+		if !ed25519.Verify(key.PublicKey, message, auth.Signature[i*ed25519.SignatureSize:(i+1)*ed25519.SignatureSize]) {
+			t.Errorf("signature %d cannot be verified", i)
+		}
+	}
+
+	encoded := bcs.MustMarshal(signedTx)
+	var decoded aptos.SignedTransaction
+	if _, err := bcs.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if reEncoded := bcs.MustMarshal(&decoded); !bytes.Equal(encoded, reEncoded) {
+		t.Fatalf("round trip changes the encoding:\nwant: %x\ngot:  %x", encoded, reEncoded)
+	}
+
+	simulationSignature, err := account.SignForSimulation(tx.Transaction)
+	if err != nil {
+		t.Fatalf("failed to sign for simulation: %v", err)
+	}
+	if simulationSignature.Bitmap != "0xc0000000" {
+		t.Fatalf("wrong bitmap for simulation: %s", simulationSignature.Bitmap)
+	}
+	if _, err := aptos.NewSignedTransaction(tx.Transaction, simulationSignature); err != nil {
+		t.Fatalf("failed to create signed transaction for simulation: %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package aptos
+
+import (
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// MultiKeyMaxSignerCount is the max number of public keys of a multi key account.
+const MultiKeyMaxSignerCount = 32
+
+// MultiKey is the public keys of a K-of-N [MultiKeyAccount], where K is SignaturesRequired.
+type MultiKey struct {
+	PublicKeys         []*AnyPublicKey `json:"public_keys"`
+	SignaturesRequired uint8           `json:"signatures_required"`
+}
+
+var _ bcs.Marshaler = (*MultiKey)(nil)
+
+func (k MultiKey) MarshalBCS() ([]byte, error) {
+	r := bcs.ULEB128Encode(len(k.PublicKeys))
+	for _, publicKey := range k.PublicKeys {
+		data, err := bcs.Marshal(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, data...)
+	}
+
+	return append(r, k.SignaturesRequired), nil
+}
+
+func (k *MultiKey) decode(b *bcsReader) {
+	count := b.uleb128()
+	k.PublicKeys = nil
+	for i := 0; i < count && b.err == nil; i++ {
+		publicKey := &AnyPublicKey{}
+		publicKey.decode(b)
+		k.PublicKeys = append(k.PublicKeys, publicKey)
+	}
+	b.decode(&k.SignaturesRequired)
+}
+
+// MultiKeyAuthenticator contains the public keys and the signatures of a [MultiKeyAccount].
+// The signatures are ordered by the index of their public keys, and the i-th bit (counting from the most significant bit of the first byte)
+// of the bitmap is set if the i-th public key signs.
+type MultiKeyAuthenticator struct {
+	PublicKeys       *MultiKey
+	Signatures       []*AnySignature
+	SignaturesBitmap []byte
+}
+
+var _ bcs.Marshaler = (*MultiKeyAuthenticator)(nil)
+
+func (a MultiKeyAuthenticator) MarshalBCS() ([]byte, error) {
+	r, err := bcs.Marshal(a.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	r = append(r, bcs.ULEB128Encode(len(a.Signatures))...)
+	for _, signature := range a.Signatures {
+		data, err := bcs.Marshal(signature)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, data...)
+	}
+
+	bitmap, err := bcs.Marshal(a.SignaturesBitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(r, bitmap...), nil
+}
+
+func (a *MultiKeyAuthenticator) decode(b *bcsReader) {
+	a.PublicKeys = &MultiKey{}
+	a.PublicKeys.decode(b)
+
+	count := b.uleb128()
+	a.Signatures = nil
+	for i := 0; i < count && b.err == nil; i++ {
+		signature := &AnySignature{}
+		signature.decode(b)
+		a.Signatures = append(a.Signatures, signature)
+	}
+
+	a.SignaturesBitmap = b.bytes()
+}
+
+// isSimulation checks if all the signatures are zero.
+func (a *MultiKeyAuthenticator) isSimulation() bool {
+	for _, signature := range a.Signatures {
+		if !signature.isSimulation() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// verify checks that the signatures are from at least the required number of public keys.
+func (a *MultiKeyAuthenticator) verify(message []byte) error {
+	if a.PublicKeys == nil {
+		return fmt.Errorf("missing public keys in multi key authenticator")
+	}
+
+	indices := decodeSignerBitmap(a.SignaturesBitmap)
+	if len(indices) != len(a.Signatures) {
+		return fmt.Errorf("bitmap %x has %d signers, but %d signatures are provided", a.SignaturesBitmap, len(indices), len(a.Signatures))
+	}
+	if a.PublicKeys.SignaturesRequired == 0 || len(indices) < int(a.PublicKeys.SignaturesRequired) {
+		return fmt.Errorf("%d signatures are provided, but %d are required", len(indices), a.PublicKeys.SignaturesRequired)
+	}
+
+	for i, index := range indices {
+		if index >= len(a.PublicKeys.PublicKeys) {
+			return fmt.Errorf("signer %d is out of %d public keys", index, len(a.PublicKeys.PublicKeys))
+		}
+		if !a.PublicKeys.PublicKeys[index].Verify(message, a.Signatures[i]) {
+			return fmt.Errorf("invalid signature of signer %d", index)
+		}
+	}
+
+	return nil
+}
+
+// multiKeySignatureJson is the json format of [MultiKeySignatureType].
+type multiKeySignatureJson struct {
+	Type               string                      `json:"type"`
+	PublicKeys         []*AnyPublicKey             `json:"public_keys"`
+	Signatures         []*multiKeyIndexedSignature `json:"signatures"`
+	SignaturesRequired uint8                       `json:"signatures_required"`
+}
+
+type multiKeyIndexedSignature struct {
+	Index     uint8         `json:"index"`
+	Signature *AnySignature `json:"signature"`
+}
+
+func newMultiKeySignatureJson(a *MultiKeyAuthenticator) (*multiKeySignatureJson, error) {
+	indices := decodeSignerBitmap(a.SignaturesBitmap)
+	if len(indices) != len(a.Signatures) {
+		return nil, fmt.Errorf("bitmap %x has %d signers, but %d signatures are provided", a.SignaturesBitmap, len(indices), len(a.Signatures))
+	}
+
+	r := &multiKeySignatureJson{
+		Type:               MultiKeySignatureType,
+		PublicKeys:         a.PublicKeys.PublicKeys,
+		SignaturesRequired: a.PublicKeys.SignaturesRequired,
+	}
+	for i, signature := range a.Signatures {
+		r.Signatures = append(r.Signatures, &multiKeyIndexedSignature{Index: uint8(indices[i]), Signature: signature})
+	}
+
+	return r, nil
+}
+
+func (r *multiKeySignatureJson) toAuthenticator() *MultiKeyAuthenticator {
+	a := &MultiKeyAuthenticator{
+		PublicKeys: &MultiKey{
+			PublicKeys:         r.PublicKeys,
+			SignaturesRequired: r.SignaturesRequired,
+		},
+	}
+	indices := make([]int, 0, len(r.Signatures))
+	for _, signature := range r.Signatures {
+		indices = append(indices, int(signature.Index))
+		a.Signatures = append(a.Signatures, signature.Signature)
+	}
+	a.SignaturesBitmap = encodeSignerBitmap(indices)
+
+	return a
+}
+
+// MultiKeyAccount is a K-of-N account, where the keys can be of different schemes.
+// This is the multi key version of [MultiEd25519Account].
+//
+// The private keys are added by [MultiKeyAccount.AddPrivateKey]. When signing, the account collects the signatures
+// from the first K private keys ordered by the index of their public keys.
+//
+// For simulation, private keys are not required and the signatures of the first K public keys are all zero.
+type MultiKeyAccount struct {
+	MultiKey
+	Address Address
+
+	// privateKeys has the same length as PublicKeys, and is nil if the private key is not added.
+	privateKeys []PrivateKey
+}
+
+var (
+	_ Signer        = (*MultiKeyAccount)(nil)
+	_ RawDataSigner = (*MultiKeyAccount)(nil)
+)
+
+// NewMultiKeyAccount creates a K-of-N account from the public keys, where K is signaturesRequired.
+// The address is the authentication key calculated by [GenerateMultiKeyAuthenticationKey].
+func NewMultiKeyAccount(signaturesRequired int, publicKeys ...*AnyPublicKey) (*MultiKeyAccount, error) {
+	address, err := GenerateMultiKeyAuthenticationKey(signaturesRequired, publicKeys...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiKeyAccount{
+		MultiKey: MultiKey{
+			PublicKeys:         publicKeys,
+			SignaturesRequired: uint8(signaturesRequired),
+		},
+		Address:     address,
+		privateKeys: make([]PrivateKey, len(publicKeys)),
+	}, nil
+}
+
+// AddPrivateKey adds the private key, whose public key must be one of the public keys of the account.
+func (account *MultiKeyAccount) AddPrivateKey(privateKey PrivateKey) error {
+	publicKey := privateKey.AnyPublicKey()
+	for i, key := range account.PublicKeys {
+		if key.Equal(publicKey) {
+			account.privateKeys[i] = privateKey
+			return nil
+		}
+	}
+
+	return fmt.Errorf("public key is not part of the account %s", account.Address)
+}
+
+func (account *MultiKeyAccount) Sign(tx *Transaction) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &account.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	return account.SignRawData(EncodeTransaction(tx))
+}
+
+func (account *MultiKeyAccount) SignForSimulation(tx *Transaction) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &account.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	return account.SignRawDataForSimulation(EncodeTransaction(tx))
+}
+
+func (account *MultiKeyAccount) SignerAddress() Address {
+	return account.Address
+}
+
+// SignRawData collects the signatures from the first K private keys, and returns a multi key signature.
+func (account *MultiKeyAccount) SignRawData(message []byte) (*SingleSignature, error) {
+	var indices []int
+	var signatures []*AnySignature
+	for i, privateKey := range account.privateKeys {
+		if len(indices) >= int(account.SignaturesRequired) {
+			break
+		}
+		if privateKey == nil {
+			continue
+		}
+
+		signature, err := privateKey.SignMessage(message)
+		if err != nil {
+			return nil, fmt.Errorf("key %d failed to sign: %w", i, err)
+		}
+
+		indices = append(indices, i)
+		signatures = append(signatures, signature)
+	}
+
+	if len(indices) < int(account.SignaturesRequired) {
+		return nil, fmt.Errorf("requires %d private keys, but only %d are added", account.SignaturesRequired, len(indices))
+	}
+
+	return account.newSingleSignature(indices, signatures), nil
+}
+
+// SignRawDataForSimulation returns all zero signatures of the first K public keys.
+func (account *MultiKeyAccount) SignRawDataForSimulation(message []byte) (*SingleSignature, error) {
+	var indices []int
+	var signatures []*AnySignature
+	for i := 0; i < int(account.SignaturesRequired); i++ {
+		indices = append(indices, i)
+		signatures = append(signatures, account.PublicKeys[i].simulationSignature())
+	}
+
+	return account.newSingleSignature(indices, signatures), nil
+}
+
+func (account *MultiKeyAccount) newSingleSignature(indices []int, signatures []*AnySignature) *SingleSignature {
+	multiKey := account.MultiKey
+
+	return &SingleSignature{
+		Type: MultiKeySignatureType,
+		MultiKey: &MultiKeyAuthenticator{
+			PublicKeys:       &multiKey,
+			Signatures:       signatures,
+			SignaturesBitmap: encodeSignerBitmap(indices),
+		},
+	}
+}
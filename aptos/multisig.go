@@ -0,0 +1,372 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+	"golang.org/x/crypto/sha3"
+)
+
+// MultisigAccountModuleName is the module of on-chain multisig accounts in aptos framework.
+//
+// Unlike [MultiEd25519Account], where the signatures of the owners are collected off-chain and submitted together,
+// the owners of an on-chain multisig account propose, approve, or reject transactions on chain,
+// and a transaction is executed by any owner once it has enough approvals.
+//
+// See [multisig_account.move].
+//
+// [multisig_account.move]: https://github.com/aptos-labs/aptos-core/blob/main/aptos-move/framework/aptos-framework/sources/multisig_account.move
+const MultisigAccountModuleName = "multisig_account"
+
+// multisigAccountDomainSeparator is the prefix of the seed to create the multisig account address.
+const multisigAccountDomainSeparator = "aptos_framework::multisig_account"
+
+// CalculateMultisigAccountAddress calculates the address of the multisig account created by the creator
+// at the sequence number of the creating transaction.
+// This is the same as the view function 0x1::multisig_account::get_next_multisig_account_address.
+func CalculateMultisigAccountAddress(creator Address, sequenceNumber uint64) Address {
+	seed := append([]byte(multisigAccountDomainSeparator), bcs.MustMarshal(sequenceNumber)...)
+
+	return CalculateResourceAddress(creator, seed)
+}
+
+// MultisigPayload executes a transaction of an on-chain multisig account. The transaction must have enough approvals.
+// This is variant 3 of [TransactionPayload].
+type MultisigPayload struct {
+	MultisigAddress Address
+	// TransactionPayload can be nil if the full payload is proposed on chain.
+	// If only the hash is proposed, the payload must be provided, and it is checked against the hash.
+	TransactionPayload *EntryFunctionPayload
+}
+
+var _ bcs.Marshaler = (*MultisigPayload)(nil)
+
+// MarshalBCS encodes the multisig address and the optional payload.
+// The only variant of MultisigTransactionPayload is entry function.
+func (p MultisigPayload) MarshalBCS() ([]byte, error) {
+	r := append([]byte{}, p.MultisigAddress[:]...)
+	if p.TransactionPayload == nil {
+		return append(r, 0), nil
+	}
+
+	payload, err := EncodeMultisigTransactionPayload(p.TransactionPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	r = append(r, 1)
+	return append(r, payload...), nil
+}
+
+func decodeMultisigPayload(b *bcsReader) *MultisigPayload {
+	r := &MultisigPayload{}
+
+	b.decode(&r.MultisigAddress)
+
+	var isSet bool
+	b.decode(&isSet)
+	if !isSet || b.err != nil {
+		return r
+	}
+
+	r.TransactionPayload = decodeMultisigTransactionPayload(b)
+
+	return r
+}
+
+// multisigPayloadJson is the json format of [MultisigPayload].
+type multisigPayloadJson struct {
+	Type               string                            `json:"type"`
+	MultisigAddress    Address                           `json:"multisig_address"`
+	TransactionPayload *transactionPayload_EntryFunction `json:"transaction_payload,omitempty"`
+}
+
+const multisigPayloadTypeStr = "multisig_payload"
+
+func (p MultisigPayload) MarshalJSON() ([]byte, error) {
+	r := multisigPayloadJson{
+		Type:            multisigPayloadTypeStr,
+		MultisigAddress: p.MultisigAddress,
+	}
+	if p.TransactionPayload != nil {
+		r.TransactionPayload = &transactionPayload_EntryFunction{
+			EntryFunctionPayload: p.TransactionPayload,
+			Type:                 entryFunctionPayloadTypeStr,
+		}
+	}
+
+	return json.Marshal(r)
+}
+
+func (p *MultisigPayload) UnmarshalJSON(data []byte) error {
+	var r multisigPayloadJson
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	p.MultisigAddress = r.MultisigAddress
+	p.TransactionPayload = nil
+	if r.TransactionPayload != nil {
+		p.TransactionPayload = r.TransactionPayload.EntryFunctionPayload
+	}
+
+	return nil
+}
+
+// EncodeMultisigTransactionPayload encodes the entry function payload proposed to a multisig account,
+// which is the bcs encoded MultisigTransactionPayload::EntryFunction.
+func EncodeMultisigTransactionPayload(payload *EntryFunctionPayload) ([]byte, error) {
+	payloadBytes, err := bcs.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{0}, payloadBytes...), nil
+}
+
+// HashMultisigTransactionPayload returns the sha3-256 of the encoded payload, which can be proposed instead of the full payload
+// by [MultisigAccount_CreateTransactionWithHash].
+func HashMultisigTransactionPayload(payload *EntryFunctionPayload) ([]byte, error) {
+	payloadBytes, err := EncodeMultisigTransactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha3.Sum256(payloadBytes)
+
+	return hash[:], nil
+}
+
+func decodeMultisigTransactionPayload(b *bcsReader) *EntryFunctionPayload {
+	variant := b.uleb128()
+	if b.err != nil {
+		return nil
+	}
+	if variant != 0 {
+		b.err = fmt.Errorf("unsupported multisig transaction payload variant: %d", variant)
+		return nil
+	}
+
+	return decodeEntryFunctionPayload(b)
+}
+
+// newMultisigAccountTransaction creates a transaction calling the entry function of the multisig_account module.
+func newMultisigAccountTransaction(sender Address, function string, arguments []*EntryFunctionArg, options ...TransactionOption) *Transaction {
+	tx := &Transaction{
+		Payload: NewEntryFunctionPayload(MustNewMoveFunctionTag(AptosStdAddress, MultisigAccountModuleName, function), nil, arguments),
+	}
+
+	ApplyTransactionOptions(tx, options...)
+
+	tx.Sender = sender
+
+	return tx
+}
+
+// MultisigAccount_CreateWithOwners creates a multisig account owned by the sender and the additional owners, which requires numSignaturesRequired approvals to execute a transaction.
+// The address of the new account can be calculated by [CalculateMultisigAccountAddress] with the sequence number of this transaction.
+func MultisigAccount_CreateWithOwners(sender Address, additionalOwners []Address, numSignaturesRequired uint64, options ...TransactionOption) *Transaction {
+	return newMultisigAccountTransaction(sender, "create_with_owners", []*EntryFunctionArg{
+		EntryFunctionArg_VectorOf(additionalOwners, EntryFunctionArg_Address),
+		EntryFunctionArg_Uint64(numSignaturesRequired),
+		// metadata keys and values
+		EntryFunctionArg_VectorOf([]string{}, EntryFunctionArg_String),
+		EntryFunctionArg_VectorOf([][]byte{}, EntryFunctionArg_U8Vector),
+	}, options...)
+}
+
+// MultisigAccount_CreateTransaction proposes the payload to the multisig account. The sender must be an owner, and the proposal counts as the sender's approval.
+func MultisigAccount_CreateTransaction(sender Address, multisigAccount Address, payload *EntryFunctionPayload, options ...TransactionOption) (*Transaction, error) {
+	payloadBytes, err := EncodeMultisigTransactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMultisigAccountTransaction(sender, "create_transaction", []*EntryFunctionArg{
+		EntryFunctionArg_Address(multisigAccount),
+		EntryFunctionArg_U8Vector(payloadBytes),
+	}, options...), nil
+}
+
+// MultisigAccount_CreateTransactionWithHash proposes the hash of the payload to the multisig account, the full payload must be provided when executed.
+// See [HashMultisigTransactionPayload].
+func MultisigAccount_CreateTransactionWithHash(sender Address, multisigAccount Address, payload *EntryFunctionPayload, options ...TransactionOption) (*Transaction, error) {
+	hash, err := HashMultisigTransactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMultisigAccountTransaction(sender, "create_transaction_with_hash", []*EntryFunctionArg{
+		EntryFunctionArg_Address(multisigAccount),
+		EntryFunctionArg_U8Vector(hash),
+	}, options...), nil
+}
+
+// MultisigAccount_ApproveTransaction approves the transaction of the sequence number on the multisig account.
+func MultisigAccount_ApproveTransaction(sender Address, multisigAccount Address, sequenceNumber uint64, options ...TransactionOption) *Transaction {
+	return newMultisigAccountTransaction(sender, "approve_transaction", []*EntryFunctionArg{
+		EntryFunctionArg_Address(multisigAccount),
+		EntryFunctionArg_Uint64(sequenceNumber),
+	}, options...)
+}
+
+// MultisigAccount_RejectTransaction rejects the transaction of the sequence number on the multisig account.
+func MultisigAccount_RejectTransaction(sender Address, multisigAccount Address, sequenceNumber uint64, options ...TransactionOption) *Transaction {
+	return newMultisigAccountTransaction(sender, "reject_transaction", []*EntryFunctionArg{
+		EntryFunctionArg_Address(multisigAccount),
+		EntryFunctionArg_Uint64(sequenceNumber),
+	}, options...)
+}
+
+// MultisigAccount_ExecuteRejectedTransaction removes the next transaction of the multisig account, which must have enough rejections.
+func MultisigAccount_ExecuteRejectedTransaction(sender Address, multisigAccount Address, options ...TransactionOption) *Transaction {
+	return newMultisigAccountTransaction(sender, "execute_rejected_transaction", []*EntryFunctionArg{
+		EntryFunctionArg_Address(multisigAccount),
+	}, options...)
+}
+
+// NewMultisigExecutionTransaction executes the next transaction of the multisig account, which must have enough approvals.
+// The sender must be an owner. The payload can be nil if the full payload is proposed.
+func NewMultisigExecutionTransaction(sender Address, multisigAccount Address, payload *EntryFunctionPayload, options ...TransactionOption) *Transaction {
+	tx := &Transaction{
+		Payload: &TransactionPayload{
+			Multisig: &MultisigPayload{
+				MultisigAddress:    multisigAccount,
+				TransactionPayload: payload,
+			},
+		},
+	}
+
+	ApplyTransactionOptions(tx, options...)
+
+	tx.Sender = sender
+
+	return tx
+}
+
+// MultisigAccount is the resource 0x1::multisig_account::MultisigAccount. Only the fields related to transactions are included.
+type MultisigAccount struct {
+	Owners                     []Address  `json:"owners"`
+	NumSignaturesRequired      JsonUint64 `json:"num_signatures_required"`
+	Transactions               Table      `json:"transactions"`
+	LastExecutedSequenceNumber JsonUint64 `json:"last_executed_sequence_number"`
+	NextSequenceNumber         JsonUint64 `json:"next_sequence_number"`
+}
+
+// MultisigAccountType is the type of [MultisigAccount].
+var MultisigAccountType = MoveStructTag{
+	MoveModuleTag: MoveModuleTag{
+		Address: AptosStdAddress,
+		Module:  MultisigAccountModuleName,
+	},
+	Name: "MultisigAccount",
+}
+
+// MultisigTransaction is 0x1::multisig_account::MultisigTransaction, a transaction proposed to a multisig account.
+// Either Payload or PayloadHash is set.
+type MultisigTransaction struct {
+	Payload          MoveOption[MoveBytecode] `json:"payload"`
+	PayloadHash      MoveOption[MoveBytecode] `json:"payload_hash"`
+	Votes            SimpleMap[Address, bool] `json:"votes"`
+	Creator          Address                  `json:"creator"`
+	CreationTimeSecs JsonUint64               `json:"creation_time_secs"`
+}
+
+// VoteCounts returns the number of approvals and rejections of the transaction.
+func (tx *MultisigTransaction) VoteCounts() (approvals int, rejections int) {
+	for _, vote := range tx.Votes.Data {
+		if vote.Value {
+			approvals++
+		} else {
+			rejections++
+		}
+	}
+
+	return
+}
+
+// DecodePayload decodes the proposed payload. Nil is returned if only the hash of the payload is proposed.
+func (tx *MultisigTransaction) DecodePayload() (*EntryFunctionPayload, error) {
+	payloadBytes, ok := tx.Payload.Get()
+	if !ok {
+		return nil, nil
+	}
+
+	b := newBcsReader(bytes.NewReader(payloadBytes))
+	r := decodeMultisigTransactionPayload(b)
+
+	return r, b.err
+}
+
+// MultisigPendingTransaction is a [MultisigTransaction] pending execution and its sequence number.
+type MultisigPendingTransaction struct {
+	SequenceNumber uint64
+	*MultisigTransaction
+}
+
+// GetMultisigAccount retrieves the [MultisigAccount] resource.
+func (client *Client) GetMultisigAccount(ctx context.Context, multisigAccount Address) (*MultisigAccount, error) {
+	return GetAccountResourceWithType[MultisigAccount](ctx, client, multisigAccount, &MultisigAccountType, 0)
+}
+
+// viewMultisigAccount calls the view function of the multisig_account module and parses the first return value.
+func viewMultisigAccount[T any](ctx context.Context, client *Client, function string, ledgerVersion *uint64, arguments ...*EntryFunctionArg) (*T, *AptosReponseHeader, error) {
+	resp, err := client.View(ctx, &ViewRequest{
+		Function:      MustNewMoveFunctionTag(AptosStdAddress, MultisigAccountModuleName, function),
+		TypeArguments: make([]*MoveTypeTag, 0),
+		Arguments:     arguments,
+		LedgerVersion: ledgerVersion,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values []json.RawMessage
+	if err := json.Unmarshal(*resp.Parsed, &values); err != nil {
+		return nil, nil, err
+	}
+	if len(values) != 1 {
+		return nil, nil, fmt.Errorf("%s returns %d values, want 1", function, len(values))
+	}
+
+	r := new(T)
+	if err := json.Unmarshal(values[0], r); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the return value of %s: %w", function, err)
+	}
+
+	return r, resp.Headers, nil
+}
+
+// GetMultisigTransaction retrieves the transaction of the sequence number on the multisig account by view function get_transaction.
+func (client *Client) GetMultisigTransaction(ctx context.Context, multisigAccount Address, sequenceNumber uint64) (*MultisigTransaction, error) {
+	r, _, err := viewMultisigAccount[MultisigTransaction](ctx, client, "get_transaction", nil, EntryFunctionArg_Address(multisigAccount), EntryFunctionArg_Uint64(sequenceNumber))
+
+	return r, err
+}
+
+// GetMultisigPendingTransactions retrieves the transactions pending execution on the multisig account by view function get_pending_transactions.
+// The sequence numbers are from the [MultisigAccount] resource at the same ledger version.
+func (client *Client) GetMultisigPendingTransactions(ctx context.Context, multisigAccount Address) ([]*MultisigPendingTransaction, error) {
+	txs, header, err := viewMultisigAccount[[]*MultisigTransaction](ctx, client, "get_pending_transactions", nil, EntryFunctionArg_Address(multisigAccount))
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := GetAccountResourceWithType[MultisigAccount](ctx, client, multisigAccount, &MultisigAccountType, header.LedgerVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]*MultisigPendingTransaction, 0, len(*txs))
+	for i, tx := range *txs {
+		r = append(r, &MultisigPendingTransaction{
+			SequenceNumber:      uint64(account.LastExecutedSequenceNumber) + 1 + uint64(i),
+			MultisigTransaction: tx,
+		})
+	}
+
+	return r, nil
+}
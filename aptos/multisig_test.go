@@ -0,0 +1,146 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func testMultisigProposal() *aptos.EntryFunctionPayload {
+	return aptos.NewEntryFunctionPayload(
+		aptos.MustNewMoveFunctionTag(aptos.AptosStdAddress, "aptos_account", "transfer"),
+		nil,
+		[]*aptos.EntryFunctionArg{aptos.EntryFunctionArg_Address(aptos.MustParseAddress("0x2")), aptos.EntryFunctionArg_Uint64(100)},
+	).EntryFunctionPayload
+}
+
+func TestMultisigPayload(t *testing.T) {
+	multisigAddress := aptos.CalculateMultisigAccountAddress(aptos.MustParseAddress("0x1234"), 5)
+	if multisigAddress == aptos.CalculateMultisigAccountAddress(aptos.MustParseAddress("0x1234"), 6) {
+		t.Fatalf("multisig account address should depend on the sequence number")
+	}
+
+	for _, proposal := range []*aptos.EntryFunctionPayload{testMultisigProposal(), nil} {
+		tx := aptos.NewMultisigExecutionTransaction(aptos.MustParseAddress("0x1234"), multisigAddress, proposal)
+
+		encoded, err := bcs.Marshal(tx.Payload)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		if encoded[0] != 3 {
+			t.Fatalf("want variant 3, got %d", encoded[0])
+		}
+		var decoded aptos.TransactionPayload
+		if _, err := bcs.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if decoded.Multisig == nil || decoded.Multisig.MultisigAddress != multisigAddress || (decoded.Multisig.TransactionPayload == nil) != (proposal == nil) {
+			t.Fatalf("wrong payload decoded: %#v", decoded.Multisig)
+		}
+		if reEncoded := bcs.MustMarshal(&decoded); !bytes.Equal(encoded, reEncoded) {
+			t.Fatalf("round trip changes the encoding:\nwant: %x\ngot:  %x", encoded, reEncoded)
+		}
+
+		jsonData, err := json.Marshal(tx.Payload)
+		if err != nil {
+			t.Fatalf("failed to marshal json: %v", err)
+		}
+		var fromJson aptos.TransactionPayload
+		if err := json.Unmarshal(jsonData, &fromJson); err != nil {
+			t.Fatalf("failed to unmarshal json: %v", err)
+		}
+		if fromJson.Multisig == nil || fromJson.Multisig.MultisigAddress != multisigAddress {
+			t.Fatalf("wrong payload from json %s", string(jsonData))
+		}
+	}
+}
+
+func TestMultisigAccount_CreateTransaction(t *testing.T) {
+	proposal := testMultisigProposal()
+	payloadBytes, err := aptos.EncodeMultisigTransactionPayload(proposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := aptos.MultisigAccount_CreateTransaction(aptos.MustParseAddress("0x1234"), aptos.MustParseAddress("0x5678"), proposal)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if got := tx.Payload.Arguments[1].Vector; got == nil || !bytes.Equal(*got, payloadBytes) {
+		t.Fatalf("wrong payload argument: %v", tx.Payload.Arguments[1])
+	}
+
+	owner := aptos.MustParseAddress("0x5678")
+	createTx := aptos.MultisigAccount_CreateWithOwners(aptos.MustParseAddress("0x1234"), []aptos.Address{owner}, 2)
+	for _, tx := range []*aptos.Transaction{tx, createTx} {
+		if _, err := json.Marshal(tx.Payload); err != nil {
+			t.Fatalf("failed to marshal payload to json: %v", err)
+		}
+	}
+	// vector<address> with 1 address, u64, empty vector<String>, and empty vector<vector<u8>>, each prefixed with its length.
+	want := append([]byte{33, 1}, owner[:]...)
+	want = append(want, 8, 2, 0, 0, 0, 0, 0, 0, 0, 1, 0, 1, 0)
+	if got := bcs.MustMarshal(createTx.Payload.EntryFunctionPayload.Arguments); !bytes.Equal(got[1:], want) {
+		t.Fatalf("wrong create with owners arguments:\nwant: %x\ngot:  %x", want, got[1:])
+	}
+
+	multisigTx := &aptos.MultisigTransaction{Payload: aptos.MoveOption[aptos.MoveBytecode]{Vec: []aptos.MoveBytecode{payloadBytes}}}
+	decoded, err := multisigTx.DecodePayload()
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if decoded.Function.String() != proposal.Function.String() || len(decoded.Arguments) != 2 {
+		t.Fatalf("wrong payload decoded: %#v", decoded)
+	}
+}
+
+func TestClient_GetMultisigPendingTransactions(t *testing.T) {
+	multisigAddress := aptos.MustParseAddress("0x5678")
+	payloadBytes, err := aptos.EncodeMultisigTransactionPayload(testMultisigProposal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/view":
+			w.Header().Set("X-Aptos-Ledger-Version", "100")
+			fmt.Fprintf(w, `[[
+{"payload": {"vec": ["0x%x"]}, "payload_hash": {"vec": []}, "votes": {"data": [{"key": "0x1", "value": true}, {"key": "0x2", "value": false}]}, "creator": "0x1", "creation_time_secs": "1"},
+{"payload": {"vec": []}, "payload_hash": {"vec": ["0x01"]}, "votes": {"data": []}, "creator": "0x2", "creation_time_secs": "2"}
+]]`, payloadBytes)
+		case strings.HasPrefix(r.URL.Path, "/accounts/"+multisigAddress.String()+"/resource/"):
+			if got := r.URL.Query().Get("ledger_version"); got != "100" {
+				t.Errorf("want resource at ledger version 100, got %s", got)
+			}
+			w.Write([]byte(`{"type": "0x1::multisig_account::MultisigAccount", "data": {"owners": ["0x1", "0x2"], "num_signatures_required": "2", "transactions": {"handle": "0x3"}, "last_executed_sequence_number": "4", "next_sequence_number": "7"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	txs, err := client.GetMultisigPendingTransactions(context.Background(), multisigAddress)
+	if err != nil {
+		t.Fatalf("failed to get pending transactions: %v", err)
+	}
+
+	if len(txs) != 2 || txs[0].SequenceNumber != 5 || txs[1].SequenceNumber != 6 {
+		t.Fatalf("wrong pending transactions: %#v", txs)
+	}
+	if approvals, rejections := txs[0].VoteCounts(); approvals != 1 || rejections != 1 {
+		t.Fatalf("want 1 approval and 1 rejection, got %d and %d", approvals, rejections)
+	}
+	if payload, err := txs[1].DecodePayload(); err != nil || payload != nil {
+		t.Fatalf("hash only proposal should have no payload: %v %v", payload, err)
+	}
+}
@@ -3,19 +3,21 @@ package aptos
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/fardream/go-bcs/bcs"
 )
 
 // TransactionPayload is payload field of a [transaction].
-// Although there are three types, script, module bundle, and entry function, only
-// entry function is supported here.
+// There are four types, script, module bundle, entry function, and multisig, and
+// only script, entry function, and multisig are supported here.
 //
 // [transaction]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/schemas/Transaction
 type TransactionPayload struct {
-	ScriptPayload       *json.RawMessage `bcs:"-"`
+	ScriptPayload       *ScriptPayload
 	ModuleBundlePayload *json.RawMessage `bcs:"-"`
 	*EntryFunctionPayload
+	Multisig *MultisigPayload
 }
 
 // transactionPayload_EntryFunction is a helper class for json de/serialization.
@@ -33,11 +35,55 @@ var (
 func (p TransactionPayload) IsBcsEnum() {
 }
 
+// variants of [TransactionPayload] in bcs.
+const (
+	transactionPayloadVariant_Script        = 0
+	transactionPayloadVariant_ModuleBundle  = 1
+	transactionPayloadVariant_EntryFunction = 2
+	transactionPayloadVariant_Multisig      = 3
+)
+
+var _ bcs.Unmarshaler = (*TransactionPayload)(nil)
+
+// UnmarshalBCS decodes the payload from bcs. Only script, entry function, and multisig payload are supported.
+func (p *TransactionPayload) UnmarshalBCS(r io.Reader) (int, error) {
+	b := newBcsReader(r)
+	p.decode(b)
+
+	return b.n, b.err
+}
+
+func (p *TransactionPayload) decode(b *bcsReader) {
+	variant := b.uleb128()
+	if b.err != nil {
+		return
+	}
+
+	switch variant {
+	case transactionPayloadVariant_Script:
+		p.ScriptPayload = decodeScriptPayload(b)
+	case transactionPayloadVariant_EntryFunction:
+		p.EntryFunctionPayload = decodeEntryFunctionPayload(b)
+	case transactionPayloadVariant_Multisig:
+		p.Multisig = decodeMultisigPayload(b)
+	default:
+		b.err = fmt.Errorf("unsupported transaction payload variant: %d", variant)
+	}
+}
+
 const entryFunctionPayloadTypeStr = "entry_function_payload"
 
 func (p TransactionPayload) MarshalJSON() ([]byte, error) {
+	if p.ScriptPayload != nil {
+		return json.Marshal(p.ScriptPayload)
+	}
+
+	if p.Multisig != nil {
+		return json.Marshal(p.Multisig)
+	}
+
 	if p.EntryFunctionPayload == nil {
-		return nil, fmt.Errorf("only entry function payload is supported")
+		return nil, fmt.Errorf("only script, entry function, and multisig payload are supported")
 	}
 
 	return json.Marshal(transactionPayload_EntryFunction{
@@ -47,17 +93,27 @@ func (p TransactionPayload) MarshalJSON() ([]byte, error) {
 }
 
 func (p *TransactionPayload) UnmarshalJSON(data []byte) error {
-	var tmp transactionPayload_EntryFunction
-	if err := json.Unmarshal(data, &tmp); err != nil {
+	var payloadType struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &payloadType); err != nil {
 		return err
 	}
 
-	switch tmp.Type {
+	switch payloadType.Type {
 	case entryFunctionPayloadTypeStr:
+		var tmp transactionPayload_EntryFunction
+		if err := json.Unmarshal(data, &tmp); err != nil {
+			return err
+		}
 		p.EntryFunctionPayload = tmp.EntryFunctionPayload
-	case "script_payload":
-		p.ScriptPayload = (*json.RawMessage)(&data)
-	case "module_bundel_payloaad":
+	case scriptPayloadTypeStr:
+		p.ScriptPayload = &ScriptPayload{}
+		return p.ScriptPayload.UnmarshalJSON(data)
+	case multisigPayloadTypeStr:
+		p.Multisig = &MultisigPayload{}
+		return p.Multisig.UnmarshalJSON(data)
+	case "module_bundle_payload":
 		p.ModuleBundlePayload = (*json.RawMessage)(&data)
 	}
 
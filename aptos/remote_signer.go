@@ -0,0 +1,402 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// Headers for the authentication of the requests to the remote signer.
+//
+// The signature is the hex encoded HMAC-SHA256 of the timestamp (unix seconds), a new line, and the request body,
+// keyed by the secret shared between the [RemoteSigner] and the [RemoteSignerServer].
+const (
+	RemoteSignerHeader_Timestamp = "X-Remote-Signer-Timestamp"
+	RemoteSignerHeader_Signature = "X-Remote-Signer-Signature"
+)
+
+// RemoteSignerMaxClockSkew is the max difference between the timestamp of the request and the clock of the [RemoteSignerServer].
+const RemoteSignerMaxClockSkew = time.Minute
+
+// RemoteSignerMaxRequestSize is the max size of the request body accepted by the [RemoteSignerServer].
+// The body is read before the request is authenticated, so it is limited.
+const RemoteSignerMaxRequestSize = 1 << 20
+
+// remoteSignerSignPath is the path to sign the transaction on the remote signer.
+const remoteSignerSignPath = "/sign"
+
+// RemoteSignRequest is the request sent to the remote signer.
+type RemoteSignRequest struct {
+	// Address of the signer.
+	Address Address `json:"address"`
+	// SigningMessage is the output of [EncodeTransaction].
+	SigningMessage string `json:"signing_message"`
+	// Simulation requests a signature for simulation, see [Signer.SignForSimulation].
+	Simulation bool `json:"simulation,omitempty"`
+	// Metadata is passed to the policy of the signer, for example, the name of the strategy sending the transaction.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// RemoteSignResponse is the signature returned from the remote signer.
+type RemoteSignResponse = SingleSignature
+
+// RemoteSignerError is returned when the remote signer rejects the request.
+type RemoteSignerError struct {
+	HttpStatusCode int
+	Message        string
+}
+
+var _ error = (*RemoteSignerError)(nil)
+
+func (e *RemoteSignerError) Error() string {
+	return fmt.Sprintf("remote signer failed: %d %s", e.HttpStatusCode, e.Message)
+}
+
+// signRemoteSignerRequest calculates the HMAC-SHA256 of the timestamp and the body.
+func signRemoteSignerRequest(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("\n"))
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
+
+// RemoteSigner is a [Signer] whose private key lives in a remote signing service, for example [RemoteSignerServer].
+// The signing message of the transaction ([EncodeTransaction]) is sent to the service over http or unix socket,
+// and the service checks the transaction against its policy before signing it.
+type RemoteSigner struct {
+	// Url of the remote signer. For unix socket, the host is ignored.
+	Url     string
+	Address Address
+	// Secret shared with the remote signer to authenticate the requests.
+	Secret []byte
+	// Metadata is sent along with every request, see [RemoteSignRequest].
+	Metadata map[string]string
+
+	httpClient *http.Client
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// NewRemoteSigner creates a new [RemoteSigner] for the address connecting to the url.
+func NewRemoteSigner(url string, address Address, secret []byte) *RemoteSigner {
+	return &RemoteSigner{
+		Url:        url,
+		Address:    address,
+		Secret:     secret,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// NewUnixSocketRemoteSigner creates a new [RemoteSigner] for the address connecting to the unix socket.
+func NewUnixSocketRemoteSigner(socketPath string, address Address, secret []byte) *RemoteSigner {
+	signer := NewRemoteSigner("http://unix", address, secret)
+	signer.httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+
+	return signer
+}
+
+func (signer *RemoteSigner) SignerAddress() Address {
+	return signer.Address
+}
+
+func (signer *RemoteSigner) Sign(tx *Transaction) (*SingleSignature, error) {
+	return signer.SignWithContext(context.Background(), tx, false)
+}
+
+// SignForSimulation gets the simulation signature from the remote signer, which doesn't need the private key,
+// but needs the public key of the signer.
+func (signer *RemoteSigner) SignForSimulation(tx *Transaction) (*SingleSignature, error) {
+	return signer.SignWithContext(context.Background(), tx, true)
+}
+
+// SignWithContext sends the transaction to the remote signer.
+func (signer *RemoteSigner) SignWithContext(ctx context.Context, tx *Transaction, simulation bool) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &signer.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	body, err := json.Marshal(&RemoteSignRequest{
+		Address:        signer.Address,
+		SigningMessage: prefixedHexString(EncodeTransaction(tx)),
+		Simulation:     simulation,
+		Metadata:       signer.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, signer.Url+remoteSignerSignPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	r.Header.Set("Content-Type", ContentTypeJson)
+	r.Header.Set(RemoteSignerHeader_Timestamp, timestamp)
+	r.Header.Set(RemoteSignerHeader_Signature, hex.EncodeToString(signRemoteSignerRequest(signer.Secret, timestamp, body)))
+
+	httpClient := signer.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	msg, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response body: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, &RemoteSignerError{HttpStatusCode: resp.StatusCode, Message: string(bytes.TrimSpace(msg))}
+	}
+
+	signature := &RemoteSignResponse{}
+	if err := json.Unmarshal(msg, signature); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w\n, response msg: %s", err, string(msg))
+	}
+
+	return signature, nil
+}
+
+// RemoteSignerPolicy decides if a transaction can be signed by the [RemoteSignerServer].
+type RemoteSignerPolicy struct {
+	// AllowedFunctions are the entry functions the transaction can call.
+	// Nil allows all the payloads, otherwise only entry function payloads calling the listed functions are allowed.
+	AllowedFunctions []*MoveFunctionTag
+	// MaxGasAmount is the max gas amount of the transaction. Zero means no limit.
+	MaxGasAmount uint64
+	// MaxGasUnitPrice is the max gas unit price of the transaction in octas. Zero means no limit.
+	MaxGasUnitPrice uint64
+	// MaxTransactionFee is the max of max gas amount times gas unit price in octas,
+	// which is the most the transaction can be charged. Zero means no limit.
+	MaxTransactionFee uint64
+	// ChainId is the chain the transaction must be for. Zero allows any chain.
+	ChainId uint8
+	// MaxExpiration is the max duration from now to the expiration of the transaction. Zero means no limit.
+	MaxExpiration time.Duration
+}
+
+// Check returns an error if the transaction is not allowed.
+func (policy *RemoteSignerPolicy) Check(tx *Transaction) error {
+	if policy.MaxGasAmount > 0 && uint64(tx.MaxGasAmount) > policy.MaxGasAmount {
+		return fmt.Errorf("max gas amount %d exceeds the limit %d", tx.MaxGasAmount, policy.MaxGasAmount)
+	}
+
+	if policy.MaxGasUnitPrice > 0 && uint64(tx.GasUnitPrice) > policy.MaxGasUnitPrice {
+		return fmt.Errorf("gas unit price %d exceeds the limit %d", tx.GasUnitPrice, policy.MaxGasUnitPrice)
+	}
+
+	if policy.MaxTransactionFee > 0 {
+		hi, fee := bits.Mul64(uint64(tx.MaxGasAmount), uint64(tx.GasUnitPrice))
+		if hi != 0 || fee > policy.MaxTransactionFee {
+			return fmt.Errorf("max transaction fee %d * %d exceeds the limit %d", tx.MaxGasAmount, tx.GasUnitPrice, policy.MaxTransactionFee)
+		}
+	}
+
+	if policy.ChainId != 0 && tx.ChainId != policy.ChainId {
+		return fmt.Errorf("chain id %d is not allowed, expecting %d", tx.ChainId, policy.ChainId)
+	}
+
+	if policy.MaxExpiration > 0 {
+		if expiration := time.Unix(int64(tx.ExpirationTimestampSecs), 0); time.Until(expiration) > policy.MaxExpiration {
+			return fmt.Errorf("expiration %s is more than %s from now", expiration, policy.MaxExpiration)
+		}
+	}
+
+	if policy.AllowedFunctions == nil {
+		return nil
+	}
+
+	if tx.Payload == nil || tx.Payload.EntryFunctionPayload == nil {
+		return fmt.Errorf("only entry function payload is allowed")
+	}
+
+	function := tx.Payload.EntryFunctionPayload.Function
+	for _, allowed := range policy.AllowedFunctions {
+		if allowed.String() == function.String() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("function %s is not allowed", function.String())
+}
+
+// RemoteSignerServer is a reference implementation of the remote signing service for [RemoteSigner].
+// It signs the transactions of the [LocalAccount] that are allowed by the policy.
+//
+// It is a [http.Handler], and can be served over tcp or unix socket by an [http.Server], which should have read and write timeouts.
+type RemoteSignerServer struct {
+	Account *LocalAccount
+	Secret  []byte
+	Policy  *RemoteSignerPolicy
+
+	// OnRequest is called after the request is authenticated and before the policy is checked, for example, to log the metadata.
+	// An error from OnRequest rejects the request.
+	OnRequest func(request *RemoteSignRequest, tx *Transaction) error
+}
+
+var _ http.Handler = (*RemoteSignerServer)(nil)
+
+// RemoteSignerMinSecretLength is the min length of the secret of the [RemoteSignerServer].
+const RemoteSignerMinSecretLength = 16
+
+// NewRemoteSignerServer creates a new [RemoteSignerServer].
+// The secret must be at least [RemoteSignerMinSecretLength] bytes.
+func NewRemoteSignerServer(account *LocalAccount, secret []byte, policy *RemoteSignerPolicy) (*RemoteSignerServer, error) {
+	if err := checkRemoteSignerSecret(secret); err != nil {
+		return nil, err
+	}
+
+	return &RemoteSignerServer{
+		Account: account,
+		Secret:  secret,
+		Policy:  policy,
+	}, nil
+}
+
+func checkRemoteSignerSecret(secret []byte) error {
+	if len(secret) < RemoteSignerMinSecretLength {
+		return fmt.Errorf("remote signer secret must be at least %d bytes, got %d", RemoteSignerMinSecretLength, len(secret))
+	}
+
+	return nil
+}
+
+func (server *RemoteSignerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != remoteSignerSignPath {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	// the server may be created without NewRemoteSignerServer, no request is accepted with a short secret.
+	if err := checkRemoteSignerSecret(server.Secret); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, RemoteSignerMaxRequestSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := server.authenticate(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	request := &RemoteSignRequest{}
+	if err := json.Unmarshal(body, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	signature, statusCode, err := server.sign(request)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJson)
+	json.NewEncoder(w).Encode(signature)
+}
+
+// authenticate checks the timestamp and the signature of the request.
+func (server *RemoteSignerServer) authenticate(header http.Header, body []byte) error {
+	timestamp := header.Get(RemoteSignerHeader_Timestamp)
+	secs, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %s", timestamp)
+	}
+	if skew := time.Since(time.Unix(secs, 0)); skew > RemoteSignerMaxClockSkew || skew < -RemoteSignerMaxClockSkew {
+		return fmt.Errorf("timestamp %s is too far from now", timestamp)
+	}
+
+	signature, err := hex.DecodeString(header.Get(RemoteSignerHeader_Signature))
+	if err != nil || !hmac.Equal(signature, signRemoteSignerRequest(server.Secret, timestamp, body)) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+// sign decodes the transaction from the signing message, checks it, and signs it.
+// The http status code is returned along with the error.
+func (server *RemoteSignerServer) sign(request *RemoteSignRequest) (*SingleSignature, int, error) {
+	if !IsAddressEqual(&request.Address, &server.Account.Address) {
+		return nil, http.StatusBadRequest, fmt.Errorf("unknown signer %s", request.Address)
+	}
+
+	message, err := parseHexString(request.SigningMessage)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to parse signing message: %w", err)
+	}
+	if !bytes.HasPrefix(message, rawTransactionPrefix) {
+		return nil, http.StatusBadRequest, fmt.Errorf("signing message is not a transaction")
+	}
+
+	tx := &Transaction{}
+	n, err := bcs.Unmarshal(message[len(rawTransactionPrefix):], tx)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	if n != len(message)-len(rawTransactionPrefix) {
+		return nil, http.StatusBadRequest, fmt.Errorf("trailing bytes after the transaction")
+	}
+
+	if server.OnRequest != nil {
+		if err := server.OnRequest(request, tx); err != nil {
+			return nil, http.StatusForbidden, err
+		}
+	}
+
+	if server.Policy != nil {
+		if err := server.Policy.Check(tx); err != nil {
+			return nil, http.StatusForbidden, err
+		}
+	}
+
+	var signature *SingleSignature
+	if request.Simulation {
+		signature, err = server.Account.SignForSimulation(tx)
+	} else {
+		signature, err = server.Account.Sign(tx)
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	return signature, http.StatusOK, nil
+}
@@ -0,0 +1,192 @@
+package aptos_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func testRemoteSignerTransaction(sender aptos.Address, function string, maxGasAmount uint64, options ...aptos.TransactionOption) *aptos.Transaction {
+	tx := &aptos.Transaction{
+		Payload: aptos.NewEntryFunctionPayload(
+			aptos.MustNewMoveFunctionTag(aptos.AptosStdAddress, "aptos_account", function),
+			nil,
+			[]*aptos.EntryFunctionArg{aptos.EntryFunctionArg_Address(aptos.MustParseAddress("0x2")), aptos.EntryFunctionArg_Uint64(100)},
+		),
+		ChainId: 4,
+	}
+	options = append([]aptos.TransactionOption{
+		aptos.TransactionOption_MaxGasAmount(maxGasAmount),
+		aptos.TransactionOption_SequenceNumber(3),
+		aptos.TransactionOption_GasUnitPrice(100),
+		aptos.TransactionOption_ExpireAfter(time.Minute),
+	}, options...)
+	aptos.ApplyTransactionOptions(tx, options...)
+	tx.Sender = sender
+
+	return tx
+}
+
+func TestRemoteSigner(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("a secret of at least 16 bytes")
+
+	server, err := aptos.NewRemoteSignerServer(account, secret, &aptos.RemoteSignerPolicy{
+		AllowedFunctions:  []*aptos.MoveFunctionTag{aptos.MustNewMoveFunctionTag(aptos.AptosStdAddress, "aptos_account", "transfer")},
+		MaxGasAmount:      1000,
+		MaxGasUnitPrice:   150,
+		MaxTransactionFee: 120000,
+		ChainId:           4,
+		MaxExpiration:     10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata map[string]string
+	server.OnRequest = func(request *aptos.RemoteSignRequest, tx *aptos.Transaction) error {
+		metadata = request.Metadata
+		return nil
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	signer := aptos.NewRemoteSigner(httpServer.URL, account.Address, secret)
+	signer.Metadata = map[string]string{"strategy": "test"}
+
+	tx := testRemoteSignerTransaction(account.Address, "transfer", 1000)
+	signature, err := signer.Sign(tx)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	want, _ := account.Sign(tx)
+	if signature.Signature != want.Signature || signature.PublicKey != want.PublicKey {
+		t.Fatalf("want signature %#v, got %#v", want, signature)
+	}
+	if metadata["strategy"] != "test" {
+		t.Fatalf("metadata is not passed to the server: %v", metadata)
+	}
+
+	simulationSignature, err := signer.SignForSimulation(tx)
+	if err != nil {
+		t.Fatalf("failed to sign for simulation: %v", err)
+	}
+	if want, _ := account.SignForSimulation(tx); simulationSignature.Signature != want.Signature || simulationSignature.PublicKey != want.PublicKey {
+		t.Fatalf("want simulation signature %#v, got %#v", want, simulationSignature)
+	}
+
+	wrongChainTx := testRemoteSignerTransaction(account.Address, "transfer", 1000)
+	wrongChainTx.ChainId = 1
+
+	for name, c := range map[string]struct {
+		signer *aptos.RemoteSigner
+		tx     *aptos.Transaction
+		status int
+	}{
+		"function not allowed": {signer, testRemoteSignerTransaction(account.Address, "create_account", 1000), http.StatusForbidden},
+		"too much gas":         {signer, testRemoteSignerTransaction(account.Address, "transfer", 1001), http.StatusForbidden},
+		"gas price too high":   {signer, testRemoteSignerTransaction(account.Address, "transfer", 100, aptos.TransactionOption_GasUnitPrice(151)), http.StatusForbidden},
+		"fee too high":         {signer, testRemoteSignerTransaction(account.Address, "transfer", 1000, aptos.TransactionOption_GasUnitPrice(121)), http.StatusForbidden},
+		"wrong chain":          {signer, wrongChainTx, http.StatusForbidden},
+		"expiration too far":   {signer, testRemoteSignerTransaction(account.Address, "transfer", 1000, aptos.TransactionOption_ExpireAfter(time.Hour)), http.StatusForbidden},
+		"wrong secret":         {aptos.NewRemoteSigner(httpServer.URL, account.Address, []byte("wrong")), tx, http.StatusUnauthorized},
+	} {
+		_, err := c.signer.Sign(c.tx)
+		var signerErr *aptos.RemoteSignerError
+		if !errors.As(err, &signerErr) || signerErr.HttpStatusCode != c.status {
+			t.Errorf("%s: want status %d, got %v", name, c.status, err)
+		}
+	}
+}
+
+func TestRemoteSigner_UnixSocket(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// unix socket path is limited in length, so t.TempDir is not used.
+	dir, err := os.MkdirTemp("", "signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socketPath := path.Join(dir, "signer.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("a secret of at least 16 bytes")
+	server, err := aptos.NewRemoteSignerServer(account, secret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := &http.Server{Handler: server}
+	go httpServer.Serve(listener)
+	defer httpServer.Close()
+
+	signer := aptos.NewUnixSocketRemoteSigner(socketPath, account.Address, secret)
+	tx := testRemoteSignerTransaction(account.Address, "create_account", 1000000)
+	signature, err := signer.Sign(tx)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if want, _ := account.Sign(tx); signature.Signature != want.Signature || signature.PublicKey != want.PublicKey {
+		t.Fatalf("want signature %#v, got %#v", want, signature)
+	}
+}
+
+func TestRemoteSigner_ShortSecret(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range [][]byte{nil, []byte("short secret")} {
+		if _, err := aptos.NewRemoteSignerServer(account, secret, nil); err == nil {
+			t.Fatalf("secret %q is accepted", secret)
+		}
+
+		httpServer := httptest.NewServer(&aptos.RemoteSignerServer{Account: account, Secret: secret})
+		_, err := aptos.NewRemoteSigner(httpServer.URL, account.Address, secret).Sign(testRemoteSignerTransaction(account.Address, "transfer", 1000))
+		httpServer.Close()
+		var signerErr *aptos.RemoteSignerError
+		if !errors.As(err, &signerErr) || signerErr.HttpStatusCode != http.StatusInternalServerError {
+			t.Fatalf("want status %d for secret %q, got %v", http.StatusInternalServerError, secret, err)
+		}
+	}
+}
+
+func TestRemoteSigner_LargeRequest(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := aptos.NewRemoteSignerServer(account, []byte("a secret of at least 16 bytes"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	body := bytes.NewReader(make([]byte, aptos.RemoteSignerMaxRequestSize+1))
+	resp, err := http.Post(httpServer.URL+"/sign", aptos.ContentTypeJson, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("want status %d, got %d", http.StatusRequestEntityTooLarge, resp.StatusCode)
+	}
+}
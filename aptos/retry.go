@@ -0,0 +1,179 @@
+package aptos
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a [Client] retries failed requests.
+//
+// Only idempotent requests (see [IsIdempotentRequest]) are retried automatically.
+// Transaction submission is handled by [Client.SubmitTransaction], which checks if the transaction has already
+// landed on chain before resubmitting it.
+//
+// The wait before the n-th retry is InitialBackoff * Scale^(n-1), capped by MaxBackoff, and randomized by Jitter.
+// If the response contains a Retry-After header, the wait will be at least that long.
+// A Retry-After longer than MaxBackoff is not honored, and the request fails without retrying.
+type RetryPolicy struct {
+	// MaxRetries is the max number of retries after the first attempt.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait calculated from exponential backoff, and the Retry-After the client is willing to wait. Zero means no cap.
+	MaxBackoff time.Duration
+	// Scale of the exponential backoff.
+	Scale float64
+	// Jitter is the fraction of the wait that is randomized, between 0 and 1.
+	// For example, a 0.2 jitter changes the wait to a random value between 80% and 120% of the calculated wait.
+	Jitter float64
+}
+
+// NewRetryPolicy creates a new [RetryPolicy] with the given max retries and initial backoff.
+// The backoff scales by 2 for each retry, is capped at 30 seconds, and has 20% of jitter.
+func NewRetryPolicy(maxRetries int, initialBackoff time.Duration) *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:     maxRetries,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     30 * time.Second,
+		Scale:          2,
+		Jitter:         0.2,
+	}
+}
+
+// ClientOption_RetryPolicy sets the retry policy of the client. Nil policy disables retry.
+type ClientOption_RetryPolicy struct {
+	*RetryPolicy
+}
+
+var _ ClientOption = (*ClientOption_RetryPolicy)(nil)
+
+func (opt ClientOption_RetryPolicy) SetClientOption(client *Client) *Client {
+	client.retryPolicy = opt.RetryPolicy
+	return client
+}
+
+// Backoff returns the wait before the retry-th retry (starting from 1), without considering Retry-After.
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	scale := p.Scale
+	if scale < 1 {
+		scale = 1
+	}
+	wait := float64(p.InitialBackoff) * math.Pow(scale, float64(retry-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		wait = wait * (1 + p.Jitter*(2*rand.Float64()-1))
+	}
+
+	return time.Duration(wait)
+}
+
+// wait blocks for the backoff of the retry-th retry, or the Retry-After returned by the server, whichever is longer.
+// err is returned immediately if the Retry-After exceeds MaxBackoff.
+func (p *RetryPolicy) wait(ctx context.Context, retry int, err error) error {
+	wait := p.Backoff(retry)
+	if restErr, ok := err.(*AptosRestError); ok {
+		if retryAfter, ok := restErr.RetryAfter(); ok && retryAfter > wait {
+			if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+				return err
+			}
+			wait = retryAfter
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RetryAfter parses the Retry-After header of the response, which can be either seconds or a http date.
+func (e *AptosRestError) RetryAfter() (time.Duration, bool) {
+	if e.Header == nil {
+		return 0, false
+	}
+	v := e.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+
+	return 0, false
+}
+
+// IsRetryableError checks if the error is transient and the request can be sent again:
+//   - [AptosRestError] with status code 429 (too many requests), 500, 502, 503, or 504.
+//   - network errors returned from the http client, unless the context is cancelled or expired.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var restErr *AptosRestError
+	if errors.As(err, &restErr) {
+		switch restErr.HttpStatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// IdempotentRequest is implemented by requests that don't use GET method, but are safe to send multiple times,
+// such as view or simulation.
+type IdempotentRequest interface {
+	IsIdempotent() bool
+}
+
+// IsIdempotentRequest checks if a request can be retried automatically.
+// GET requests are always idempotent, other requests are idempotent if they implement [IdempotentRequest].
+func IsIdempotentRequest(request AptosRequest) bool {
+	if request.HttpMethod() == http.MethodGet || request.HttpMethod() == http.MethodHead {
+		return true
+	}
+	if r, ok := request.(IdempotentRequest); ok {
+		return r.IsIdempotent()
+	}
+
+	return false
+}
+
+// doRequestWithRetry calls [doRequestWithFailover], and retries if the request is retryable and the client has a [RetryPolicy].
+func doRequestWithRetry[TResponse any](ctx context.Context, client *Client, retryable bool, request *restRequest) (*AptosResponse[TResponse], error) {
+	policy := client.retryPolicy
+	for retry := 0; ; retry++ {
+		resp, err := doRequestWithFailover[TResponse](ctx, client, retryable, request)
+		if err == nil || !retryable || policy == nil || retry >= policy.MaxRetries || !IsRetryableError(err) {
+			return resp, err
+		}
+
+		if waitErr := policy.wait(ctx, retry+1, err); waitErr != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package aptos_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func TestRetryPolicy_Idempotent(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(testLedgerInfoJson))
+	}))
+	defer server.Close()
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_RetryPolicy{RetryPolicy: aptos.NewRetryPolicy(3, time.Millisecond)},
+	)
+
+	if _, err := client.GetLedgerInfo(context.Background()); err != nil {
+		t.Fatalf("failed to get ledger info: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("want 3 attempts, got %d", count)
+	}
+}
+
+func TestRetryPolicy_GiveUp(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_RetryPolicy{RetryPolicy: aptos.NewRetryPolicy(3, time.Millisecond)},
+	)
+
+	_, err := client.GetLedgerInfo(context.Background())
+	if err == nil {
+		t.Fatalf("want error")
+	}
+	if count != 1 {
+		t.Fatalf("bad request should not be retried, got %d attempts", count)
+	}
+}
+
+func TestRetryPolicy_LongRetryAfter(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_RetryPolicy{RetryPolicy: aptos.NewRetryPolicy(3, time.Millisecond)},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.GetLedgerInfo(ctx)
+	if err == nil || ctx.Err() != nil {
+		t.Fatalf("want error without waiting for retry after, got %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("retry after longer than max backoff should not be retried, got %d attempts", count)
+	}
+}
+
+func TestClient_SubmitTransaction_AlreadyLanded(t *testing.T) {
+	signedTx := testSignedTransaction1(t)
+	hash, err := signedTx.GetHashString()
+	if err != nil {
+		t.Fatalf("failed to get hash: %v", err)
+	}
+
+	var submitCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/transactions":
+			atomic.AddInt32(&submitCount, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		case r.Method == http.MethodGet && r.URL.Path == "/transactions/by_hash/"+hash:
+			fmt.Fprintf(w, `{"type": "user_transaction", "hash": "%s", "sender": "%s", "sequence_number": "2625"}`, hash, signedTx.Sender)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := aptos.ApplyClientOptions(
+		aptos.MustNewClient(aptos.Localnet, server.URL),
+		aptos.ClientOption_RetryPolicy{RetryPolicy: aptos.NewRetryPolicy(3, time.Millisecond)},
+	)
+
+	resp, err := client.SubmitTransaction(context.Background(), &aptos.SubmitTransactionRequest{
+		Transaction: signedTx.Transaction,
+		Signature: aptos.SingleSignature{
+			Type:      aptos.Ed25519SignatureType,
+			PublicKey: "0xff0ef0e5910c05c24551f135485a1fcb3bced53e20996bd26bfc23aa8816756c",
+			Signature: "0x05c4586e2b47fa4d81a2fab4b6ac7975fb4d2a410d507bada48b8a0adff14c1dd18a9c8342076a133e7f6b358efba0bff777c3cc78f1131f5c5d617329b8200a",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+
+	if resp.Parsed.Hash != hash {
+		t.Fatalf("want landed transaction %s, got %s", hash, resp.Parsed.Hash)
+	}
+
+	if submitCount != 1 {
+		t.Fatalf("transaction should be submitted once, got %d", submitCount)
+	}
+}
@@ -0,0 +1,395 @@
+package aptos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// ScriptPayload executes a compiled move script in the transaction.
+// Unlike entry functions, scripts are not published on chain, and can call multiple functions atomically.
+//
+// See [script payload].
+//
+// [script payload]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/schemas/ScriptPayload
+type ScriptPayload struct {
+	// Code is the compiled bytecode of the script.
+	Code MoveBytecode
+	// TypeArguments for the generic type parameters of the script.
+	TypeArguments []*MoveTypeTag
+	// Arguments of the script, excluding the signers.
+	Arguments []*ScriptArgument
+}
+
+// scriptPayloadJson is the json format of the script payload.
+type scriptPayloadJson struct {
+	Code struct {
+		Bytecode MoveBytecode            `json:"bytecode"`
+		Abi      *MoveModuleABI_Function `json:"abi,omitempty"`
+	} `json:"code"`
+	TypeArguments []*MoveTypeTag    `json:"type_arguments"`
+	Arguments     []json.RawMessage `json:"arguments"`
+	Type          string            `json:"type"`
+}
+
+const scriptPayloadTypeStr = "script_payload"
+
+// NewScriptPayload creates a transaction payload to execute the script.
+func NewScriptPayload(code []byte, typeArguments []*MoveTypeTag, arguments []*ScriptArgument) *TransactionPayload {
+	r := &ScriptPayload{
+		Code:          code,
+		TypeArguments: typeArguments,
+		Arguments:     arguments,
+	}
+
+	if r.TypeArguments == nil {
+		r.TypeArguments = make([]*MoveTypeTag, 0)
+	}
+	if r.Arguments == nil {
+		r.Arguments = make([]*ScriptArgument, 0)
+	}
+
+	return &TransactionPayload{
+		ScriptPayload: r,
+	}
+}
+
+func (p ScriptPayload) MarshalJSON() ([]byte, error) {
+	r := scriptPayloadJson{
+		TypeArguments: p.TypeArguments,
+		Type:          scriptPayloadTypeStr,
+	}
+	r.Code.Bytecode = p.Code
+	if r.TypeArguments == nil {
+		r.TypeArguments = make([]*MoveTypeTag, 0)
+	}
+	r.Arguments = make([]json.RawMessage, 0, len(p.Arguments))
+	for i, arg := range p.Arguments {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal argument %d: %w", i, err)
+		}
+		r.Arguments = append(r.Arguments, data)
+	}
+
+	return json.Marshal(r)
+}
+
+// UnmarshalJSON for [ScriptPayload].
+// If the abi of the script is included (which is the case for transactions returned from the node),
+// the arguments are parsed according to the parameter types of the script.
+// Otherwise, the arguments are parsed by heuristics, see [ScriptArgument.UnmarshalJSON].
+func (p *ScriptPayload) UnmarshalJSON(data []byte) error {
+	var r scriptPayloadJson
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	p.Code = r.Code.Bytecode
+	p.TypeArguments = r.TypeArguments
+	p.Arguments = make([]*ScriptArgument, 0, len(r.Arguments))
+
+	var paramTypes []string
+	if r.Code.Abi != nil {
+		for _, param := range r.Code.Abi.Params {
+			if param != "signer" && param != "&signer" {
+				paramTypes = append(paramTypes, param)
+			}
+		}
+		if len(paramTypes) != len(r.Arguments) {
+			paramTypes = nil
+		}
+	}
+
+	for i, rawArg := range r.Arguments {
+		arg := &ScriptArgument{}
+		var err error
+		if paramTypes != nil {
+			err = arg.unmarshalJSONWithType(rawArg, paramTypes[i])
+		} else {
+			err = arg.UnmarshalJSON(rawArg)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse argument %d: %w", i, err)
+		}
+		p.Arguments = append(p.Arguments, arg)
+	}
+
+	return nil
+}
+
+// decodeScriptPayload decodes the script payload from bcs.
+func decodeScriptPayload(b *bcsReader) *ScriptPayload {
+	r := &ScriptPayload{
+		Code:          b.bytes(),
+		TypeArguments: make([]*MoveTypeTag, 0),
+		Arguments:     make([]*ScriptArgument, 0),
+	}
+
+	typeArgCount := b.uleb128()
+	for i := 0; i < typeArgCount && b.err == nil; i++ {
+		r.TypeArguments = append(r.TypeArguments, decodeMoveTypeTag(b))
+	}
+
+	argCount := b.uleb128()
+	for i := 0; i < argCount && b.err == nil; i++ {
+		r.Arguments = append(r.Arguments, decodeScriptArgument(b))
+	}
+
+	return r
+}
+
+// ScriptArgument is the argument to a script.
+// Different from [EntryFunctionArg], script arguments are a [bcs.Enum], and only a limited set of types are supported.
+//
+// See [TransactionArgument in aptos-core].
+//
+// [TransactionArgument in aptos-core]: https://github.com/aptos-labs/aptos-core/blob/main/third_party/move/move-core/types/src/transaction_argument.rs
+type ScriptArgument struct {
+	Uint8    *uint8       // 0
+	Uint64   *JsonUint64  // 1
+	Uint128  *bcs.Uint128 // 2
+	Address  *Address     // 3
+	U8Vector *[]byte      // 4
+	Bool     *bool        // 5
+	Uint16   *uint16      // 6
+	Uint32   *uint32      // 7
+	Uint256  *Uint256     // 8
+}
+
+var (
+	_ bcs.Enum         = (*ScriptArgument)(nil)
+	_ json.Marshaler   = (*ScriptArgument)(nil)
+	_ json.Unmarshaler = (*ScriptArgument)(nil)
+)
+
+func (s ScriptArgument) IsBcsEnum() {}
+
+// ScriptArgument_Uint8 creates a u8 script argument.
+func ScriptArgument_Uint8(v uint8) *ScriptArgument {
+	return &ScriptArgument{Uint8: &v}
+}
+
+// ScriptArgument_Uint16 creates a u16 script argument.
+func ScriptArgument_Uint16(v uint16) *ScriptArgument {
+	return &ScriptArgument{Uint16: &v}
+}
+
+// ScriptArgument_Uint32 creates a u32 script argument.
+func ScriptArgument_Uint32(v uint32) *ScriptArgument {
+	return &ScriptArgument{Uint32: &v}
+}
+
+// ScriptArgument_Uint64 creates a u64 script argument.
+func ScriptArgument_Uint64(v uint64) *ScriptArgument {
+	r := JsonUint64(v)
+	return &ScriptArgument{Uint64: &r}
+}
+
+// ScriptArgument_Uint128 creates a u128 script argument from lo/hi [uint64].
+func ScriptArgument_Uint128(lo uint64, hi uint64) *ScriptArgument {
+	return &ScriptArgument{Uint128: bcs.NewUint128FromUint64(lo, hi)}
+}
+
+// ScriptArgument_Uint256 creates a u256 script argument.
+func ScriptArgument_Uint256(v *Uint256) *ScriptArgument {
+	r := *v
+	return &ScriptArgument{Uint256: &r}
+}
+
+// ScriptArgument_Address creates an address script argument.
+func ScriptArgument_Address(v Address) *ScriptArgument {
+	return &ScriptArgument{Address: &v}
+}
+
+// ScriptArgument_U8Vector creates a vector<u8> script argument.
+func ScriptArgument_U8Vector(v []byte) *ScriptArgument {
+	r := append([]byte{}, v...)
+	return &ScriptArgument{U8Vector: &r}
+}
+
+// ScriptArgument_Bool creates a bool script argument.
+func ScriptArgument_Bool(v bool) *ScriptArgument {
+	return &ScriptArgument{Bool: &v}
+}
+
+func (s *ScriptArgument) reset() {
+	s.Uint8 = nil
+	s.Uint64 = nil
+	s.Uint128 = nil
+	s.Address = nil
+	s.U8Vector = nil
+	s.Bool = nil
+	s.Uint16 = nil
+	s.Uint32 = nil
+	s.Uint256 = nil
+}
+
+// decodeScriptArgument decodes the script argument from bcs.
+func decodeScriptArgument(b *bcsReader) *ScriptArgument {
+	variant := b.uleb128()
+	if b.err != nil {
+		return nil
+	}
+
+	r := &ScriptArgument{}
+	switch variant {
+	case 0:
+		r.Uint8 = new(uint8)
+		b.decode(r.Uint8)
+	case 1:
+		r.Uint64 = new(JsonUint64)
+		b.decode(r.Uint64)
+	case 2:
+		r.Uint128 = new(bcs.Uint128)
+		b.decode(r.Uint128)
+	case 3:
+		r.Address = new(Address)
+		b.decode(r.Address)
+	case 4:
+		r.U8Vector = new([]byte)
+		*r.U8Vector = b.bytes()
+	case 5:
+		r.Bool = new(bool)
+		b.decode(r.Bool)
+	case 6:
+		r.Uint16 = new(uint16)
+		b.decode(r.Uint16)
+	case 7:
+		r.Uint32 = new(uint32)
+		b.decode(r.Uint32)
+	case 8:
+		r.Uint256 = new(Uint256)
+		b.decode(r.Uint256)
+	default:
+		b.err = fmt.Errorf("unsupported script argument variant: %d", variant)
+	}
+
+	return r
+}
+
+// MarshalJSON encodes the argument the same way as the node: u8, u16, u32 are numbers, u64, u128, and u256 are strings,
+// and vector<u8> is a 0x prefixed hex string.
+func (s ScriptArgument) MarshalJSON() ([]byte, error) {
+	switch {
+	case s.Uint8 != nil:
+		return json.Marshal(*s.Uint8)
+	case s.Uint16 != nil:
+		return json.Marshal(*s.Uint16)
+	case s.Uint32 != nil:
+		return json.Marshal(*s.Uint32)
+	case s.Uint64 != nil:
+		return json.Marshal(*s.Uint64)
+	case s.Uint128 != nil:
+		return json.Marshal(*s.Uint128)
+	case s.Uint256 != nil:
+		return json.Marshal(*s.Uint256)
+	case s.Address != nil:
+		return json.Marshal(*s.Address)
+	case s.U8Vector != nil:
+		return json.Marshal(prefixedHexString(*s.U8Vector))
+	case s.Bool != nil:
+		return json.Marshal(*s.Bool)
+	default:
+		return nil, fmt.Errorf("unset script argument")
+	}
+}
+
+// UnmarshalJSON for [ScriptArgument]. json doesn't have the type information, and the following heuristics are used:
+//   - bool is parsed to bool.
+//   - number is parsed to u64.
+//   - string of decimal digits is parsed to u64, or u128/u256 if it overflows u64/u128.
+//   - 0x prefixed string is parsed to address if it is a valid address, otherwise vector<u8>.
+//
+// The heuristics can be wrong, for example a u8 argument will be parsed as u64.
+// The argument should be parsed with the abi of the script when available (see [ScriptPayload.UnmarshalJSON]).
+func (s *ScriptArgument) UnmarshalJSON(data []byte) error {
+	s.reset()
+
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		s.Bool = &b
+		return nil
+	}
+
+	var n uint64
+	if err := json.Unmarshal(data, &n); err == nil {
+		s.Uint64 = (*JsonUint64)(&n)
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("failed to unmarshal script argument %s", string(data))
+	}
+
+	if strings.HasPrefix(str, "0x") {
+		if addr, err := ParseAddress(str); err == nil {
+			s.Address = &addr
+			return nil
+		}
+		return s.unmarshalJSONWithType(data, "vector<u8>")
+	}
+
+	bigI, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal script argument %s", str)
+	}
+	if bigI.IsUint64() {
+		return s.unmarshalJSONWithType(data, "u64")
+	}
+
+	if bigI.BitLen() <= 128 {
+		return s.unmarshalJSONWithType(data, "u128")
+	}
+
+	return s.unmarshalJSONWithType(data, "u256")
+}
+
+// unmarshalJSONWithType parses the argument as the move type.
+func (s *ScriptArgument) unmarshalJSONWithType(data []byte, moveType string) error {
+	s.reset()
+
+	switch moveType {
+	case "bool":
+		s.Bool = new(bool)
+		return json.Unmarshal(data, s.Bool)
+	case "u8":
+		s.Uint8 = new(uint8)
+		return json.Unmarshal(data, s.Uint8)
+	case "u16":
+		s.Uint16 = new(uint16)
+		return json.Unmarshal(data, s.Uint16)
+	case "u32":
+		s.Uint32 = new(uint32)
+		return json.Unmarshal(data, s.Uint32)
+	case "u64":
+		s.Uint64 = new(JsonUint64)
+		return json.Unmarshal(data, s.Uint64)
+	case "u128":
+		s.Uint128 = new(bcs.Uint128)
+		return json.Unmarshal(data, s.Uint128)
+	case "u256":
+		s.Uint256 = new(Uint256)
+		return json.Unmarshal(data, s.Uint256)
+	case "address":
+		s.Address = new(Address)
+		return json.Unmarshal(data, s.Address)
+	case "vector<u8>":
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		v, err := parseHexString(str)
+		if err != nil {
+			return err
+		}
+		s.U8Vector = &v
+		return nil
+	default:
+		return fmt.Errorf("unsupported script argument type: %s", moveType)
+	}
+}
@@ -0,0 +1,107 @@
+package aptos_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func TestScriptPayload_BCS(t *testing.T) {
+	code := []byte{0xa1, 0x1c, 0xeb, 0x0b}
+	payload := aptos.NewScriptPayload(
+		code,
+		[]*aptos.MoveTypeTag{must(aptos.ParseMoveTypeTag("u64"))},
+		[]*aptos.ScriptArgument{
+			aptos.ScriptArgument_Uint64(5),
+			aptos.ScriptArgument_Address(aptos.AptosStdAddress),
+			aptos.ScriptArgument_Bool(true),
+			aptos.ScriptArgument_U8Vector([]byte{1, 2}),
+		},
+	)
+
+	encoded, err := bcs.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	want := "00" + "04a11ceb0b" + "01" + "02" + "04" +
+		"01" + "0500000000000000" +
+		"03" + "0000000000000000000000000000000000000000000000000000000000000001" +
+		"05" + "01" +
+		"04" + "020102"
+	if hex.EncodeToString(encoded) != want {
+		t.Fatalf("wrong encoding:\nwant: %s\ngot:  %x", want, encoded)
+	}
+
+	signedTx := &aptos.SignedTransaction{
+		Transaction: &aptos.Transaction{
+			Sender:         aptos.AptosStdAddress,
+			SequenceNumber: 1,
+			Payload:        payload,
+			MaxGasAmount:   2000,
+			GasUnitPrice:   100,
+			ChainId:        4,
+		},
+		Authenticator: &aptos.TransactionAuthenticator{
+			Ed25519: &aptos.Ed25519Authenticator{PublicKey: make([]byte, 32), Signature: make([]byte, 64)},
+		},
+	}
+	txBytes := bcs.MustMarshal(signedTx)
+
+	var decoded aptos.SignedTransaction
+	if _, err := bcs.Unmarshal(txBytes, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if decoded.Payload.ScriptPayload == nil || !bytes.Equal(decoded.Payload.ScriptPayload.Code, code) {
+		t.Fatalf("script is not decoded: %#v", decoded.Payload)
+	}
+	if !bytes.Equal(bcs.MustMarshal(&decoded), txBytes) {
+		t.Fatalf("round trip changes the encoding")
+	}
+}
+
+func TestScriptPayload_JSON(t *testing.T) {
+	const fetched = `{
+  "code": {
+    "bytecode": "0xa11ceb0b",
+    "abi": {
+      "name": "main",
+      "visibility": "public",
+      "is_entry": true,
+      "generic_type_params": [],
+      "params": ["&signer", "u8", "u64", "address", "vector<u8>"],
+      "return": []
+    }
+  },
+  "type_arguments": [],
+  "arguments": [5, "100", "0x1", "0x0102"],
+  "type": "script_payload"
+}`
+
+	var payload aptos.TransactionPayload
+	if err := json.Unmarshal([]byte(fetched), &payload); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	script := payload.ScriptPayload
+	if script == nil || payload.EntryFunctionPayload != nil {
+		t.Fatalf("want script payload, got %#v", payload)
+	}
+	args := script.Arguments
+	if len(args) != 4 || *args[0].Uint8 != 5 || *args[1].Uint64 != 100 || *args[2].Address != aptos.AptosStdAddress || !bytes.Equal(*args[3].U8Vector, []byte{1, 2}) {
+		t.Fatalf("wrong arguments: %#v", args)
+	}
+
+	encoded, err := json.Marshal(&payload)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := `{"code":{"bytecode":"0xa11ceb0b"},"type_arguments":[],"arguments":[5,"100","0x1","0x0102"],"type":"script_payload"}`
+	if string(encoded) != want {
+		t.Fatalf("wrong json:\nwant: %s\ngot:  %s", want, encoded)
+	}
+}
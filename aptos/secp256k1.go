@@ -0,0 +1,117 @@
+package aptos
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// Secp256k1PrivateKeyLength is the length of a secp256k1 private key.
+const Secp256k1PrivateKeyLength = 32
+
+// Secp256k1PublicKeyLength is the length of an uncompressed secp256k1 public key, which is 0x04 followed by x and y.
+const Secp256k1PublicKeyLength = 65
+
+// Secp256k1SignatureLength is the length of a secp256k1 ecdsa signature, which is r followed by s.
+const Secp256k1SignatureLength = 64
+
+// Secp256k1PrivateKey is a 32 byte secp256k1 private key.
+//
+// Aptos signs the sha3-256 of the message with ecdsa, and only accepts signatures with low s.
+// The curve operations are done by [github.com/decred/dcrd/dcrec/secp256k1/v4], which is constant time,
+// and the nonce is derived deterministically according to RFC 6979.
+type Secp256k1PrivateKey []byte
+
+// Secp256k1PublicKey is a 65 byte uncompressed secp256k1 public key.
+type Secp256k1PublicKey []byte
+
+// NewSecp256k1PrivateKey creates a private key from the bytes.
+func NewSecp256k1PrivateKey(key []byte) (Secp256k1PrivateKey, error) {
+	if len(key) != Secp256k1PrivateKeyLength {
+		return nil, fmt.Errorf("secp256k1 private key must be %d bytes, got %d", Secp256k1PrivateKeyLength, len(key))
+	}
+	var d secp256k1.ModNScalar
+	if overflow := d.SetByteSlice(key); overflow || d.IsZero() {
+		return nil, fmt.Errorf("secp256k1 private key is out of range")
+	}
+
+	return append(Secp256k1PrivateKey{}, key...), nil
+}
+
+// GenerateSecp256k1PrivateKey generates a private key from the random source, [crypto/rand.Reader] is used if r is nil.
+func GenerateSecp256k1PrivateKey(r io.Reader) (Secp256k1PrivateKey, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	key, err := secp256k1.GeneratePrivateKeyFromRand(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Serialize(), nil
+}
+
+func (key Secp256k1PrivateKey) toPrivateKey() (*secp256k1.PrivateKey, error) {
+	if _, err := NewSecp256k1PrivateKey(key); err != nil {
+		return nil, err
+	}
+
+	return secp256k1.PrivKeyFromBytes(key), nil
+}
+
+// PublicKey returns the uncompressed public key.
+func (key Secp256k1PrivateKey) PublicKey() Secp256k1PublicKey {
+	return secp256k1.PrivKeyFromBytes(key).PubKey().SerializeUncompressed()
+}
+
+// Sign signs the sha3-256 of the message, and returns r and s of the signature.
+func (key Secp256k1PrivateKey) Sign(message []byte) ([]byte, error) {
+	hash := sha3.Sum256(message)
+	return key.SignHash(hash[:])
+}
+
+// SignHash signs the 32 byte hash, and returns r and s of the signature with low s.
+// Use [Secp256k1PrivateKey.Sign] to sign the messages for aptos.
+func (key Secp256k1PrivateKey) SignHash(hash []byte) ([]byte, error) {
+	privateKey, err := key.toPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	defer privateKey.Zero()
+
+	// the compact signature is the recovery code followed by r and s, and s is always low.
+	compact := ecdsa.SignCompact(privateKey, hash, false)
+
+	return compact[1:], nil
+}
+
+// Verify checks the signature of the sha3-256 of the message. Signatures with high s are rejected, as on chain.
+func (publicKey Secp256k1PublicKey) Verify(message []byte, signature []byte) bool {
+	hash := sha3.Sum256(message)
+	return publicKey.VerifyHash(hash[:], signature)
+}
+
+// VerifyHash checks the signature of the hash. Signatures with high s are rejected.
+func (publicKey Secp256k1PublicKey) VerifyHash(hash []byte, signature []byte) bool {
+	if len(publicKey) != Secp256k1PublicKeyLength || publicKey[0] != 4 || len(signature) != Secp256k1SignatureLength {
+		return false
+	}
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(signature[:32]); overflow || r.IsZero() {
+		return false
+	}
+	if overflow := s.SetByteSlice(signature[32:]); overflow || s.IsZero() || s.IsOverHalfOrder() {
+		return false
+	}
+
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pub)
+}
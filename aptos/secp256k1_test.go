@@ -0,0 +1,135 @@
+package aptos_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+// key pair generated by openssl ecparam -name secp256k1 -genkey.
+const (
+	testSecp256k1PrivateKey = "cee8b0413974bc9d217714d1633f88f4a2972948da09fa3320de92adc36c0306"
+	testSecp256k1PublicKey  = "04c572e9bd463c4b4a440aecf46f00be4f25245bace98d752501c71f8f3caeb35122f892f1eef12f493a80b058e515b19c349f563ada11471d7b257094830c9a32"
+)
+
+func testSecp256k1Key(t *testing.T) aptos.Secp256k1PrivateKey {
+	t.Helper()
+
+	keyBytes, _ := hex.DecodeString(testSecp256k1PrivateKey)
+	key, err := aptos.NewSecp256k1PrivateKey(keyBytes)
+	if err != nil {
+		t.Fatalf("failed to create private key: %v", err)
+	}
+
+	return key
+}
+
+func TestSecp256k1PrivateKey(t *testing.T) {
+	key := testSecp256k1Key(t)
+
+	publicKey := key.PublicKey()
+	if hex.EncodeToString(publicKey) != testSecp256k1PublicKey {
+		t.Fatalf("want public key %s, got %x", testSecp256k1PublicKey, publicKey)
+	}
+
+	message := []byte("hello aptos")
+	signature, err := key.Sign(message)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	if !publicKey.Verify(message, signature) {
+		t.Fatalf("failed to verify signature")
+	}
+	if publicKey.Verify([]byte("hello aptos!"), signature) {
+		t.Fatalf("signature of a different message should fail")
+	}
+
+	// the high s version of the signature is rejected.
+	n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	highS := append([]byte{}, signature...)
+	new(big.Int).Sub(n, new(big.Int).SetBytes(signature[32:])).FillBytes(highS[32:])
+	if bytes.Equal(highS, signature) || publicKey.Verify(message, highS) {
+		t.Fatalf("high s signature should fail")
+	}
+
+	if _, err := aptos.NewSecp256k1PrivateKey(make([]byte, 32)); err == nil {
+		t.Fatalf("zero private key should fail")
+	}
+
+	generated, err := aptos.GenerateSecp256k1PrivateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if generatedSignature, _ := generated.Sign(message); !generated.PublicKey().Verify(message, generatedSignature) {
+		t.Fatalf("failed to verify signature of generated key")
+	}
+}
+
+// published secp256k1 test vectors: the multiples of the generator, and the RFC 6979 deterministic signatures
+// over the sha256 of the messages used by python-ecdsa and trezor-crypto.
+var secp256k1TestVectors = []struct {
+	privateKey string
+	publicKey  string
+	message    string
+	signature  string
+}{
+	{
+		privateKey: "0000000000000000000000000000000000000000000000000000000000000001",
+		publicKey:  "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		message:    "Satoshi Nakamoto",
+		signature:  "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+	},
+	{
+		privateKey: "0000000000000000000000000000000000000000000000000000000000000001",
+		publicKey:  "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		message:    "All those moments will be lost in time, like tears in rain. Time to die...",
+		signature:  "8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+	},
+	{
+		privateKey: "0000000000000000000000000000000000000000000000000000000000000002",
+		publicKey:  "04c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee51ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+	},
+	{
+		privateKey: "0000000000000000000000000000000000000000000000000000000000000003",
+		publicKey:  "04f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672",
+	},
+	{
+		privateKey: "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+		publicKey:  "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798b7c52588d95c3b9aa25b0403f1eef75702e84bb7597aabe663b82f6f04ef2777",
+	},
+}
+
+func TestSecp256k1_TestVectors(t *testing.T) {
+	for _, v := range secp256k1TestVectors {
+		keyBytes, _ := hex.DecodeString(v.privateKey)
+		key, err := aptos.NewSecp256k1PrivateKey(keyBytes)
+		if err != nil {
+			t.Fatalf("failed to create private key %s: %v", v.privateKey, err)
+		}
+
+		publicKey := key.PublicKey()
+		if hex.EncodeToString(publicKey) != v.publicKey {
+			t.Errorf("public key of %s:\nwant: %s\ngot:  %x", v.privateKey, v.publicKey, publicKey)
+		}
+
+		if v.message == "" {
+			continue
+		}
+		hash := sha256.Sum256([]byte(v.message))
+		signature, err := key.SignHash(hash[:])
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		if hex.EncodeToString(signature) != v.signature {
+			t.Errorf("signature of %q by %s:\nwant: %s\ngot:  %x", v.message, v.privateKey, v.signature, signature)
+		}
+		if !publicKey.VerifyHash(hash[:], signature) {
+			t.Errorf("failed to verify signature of %q by %s", v.message, v.privateKey)
+		}
+	}
+}
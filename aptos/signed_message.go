@@ -0,0 +1,220 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SignedMessagePrefix is the first line of the message signed by [SignMessage].
+const SignedMessagePrefix = "APTOS"
+
+// MessageSigner is a [RawDataSigner] with an address, for example [LocalAccount], which can sign messages by [SignMessage].
+type MessageSigner interface {
+	RawDataSigner
+	SignerAddress() Address
+}
+
+// SignMessagePayload is the input of [SignMessage], which follows the signMessage api of aptos wallets.
+//
+// The signed message is the following lines joined by new line. Address, application, and chain id are optional.
+//
+//	APTOS
+//	address: <address>
+//	application: <application>
+//	chainId: <chain id>
+//	message: <message>
+//	nonce: <nonce>
+type SignMessagePayload struct {
+	// IncludeAddress includes the address of the signer in the message.
+	IncludeAddress bool
+	// Application is the domain of the application. It is included if not empty.
+	Application string
+	// ChainId is included if not nil.
+	ChainId *uint8
+	// Message to sign.
+	Message string
+	// Nonce is provided by the verifier to prevent replay.
+	Nonce string
+}
+
+// SignedMessage is the output of [SignMessage].
+type SignedMessage struct {
+	// Address is set if it is included in the message.
+	Address     *Address         `json:"address,omitempty"`
+	Application string           `json:"application,omitempty"`
+	ChainId     *uint8           `json:"chainId,omitempty"`
+	Message     string           `json:"message"`
+	Nonce       string           `json:"nonce"`
+	Prefix      string           `json:"prefix"`
+	FullMessage string           `json:"fullMessage"`
+	Signature   *SingleSignature `json:"signature"`
+}
+
+// newFullMessage constructs the message to sign.
+func newFullMessage(m *SignedMessage) string {
+	var sb strings.Builder
+	sb.WriteString(SignedMessagePrefix)
+	if m.Address != nil {
+		fmt.Fprintf(&sb, "\naddress: %s", prefixedHexString(m.Address[:]))
+	}
+	if m.Application != "" {
+		fmt.Fprintf(&sb, "\napplication: %s", m.Application)
+	}
+	if m.ChainId != nil {
+		fmt.Fprintf(&sb, "\nchainId: %d", *m.ChainId)
+	}
+	fmt.Fprintf(&sb, "\nmessage: %s\nnonce: %s", m.Message, m.Nonce)
+
+	return sb.String()
+}
+
+// SignMessage signs the message in the format of the signMessage api of aptos wallets.
+func SignMessage(signer MessageSigner, payload *SignMessagePayload) (*SignedMessage, error) {
+	if strings.Contains(payload.Nonce, "\n") {
+		return nil, fmt.Errorf("nonce cannot contain new line")
+	}
+
+	r := &SignedMessage{
+		Application: payload.Application,
+		ChainId:     payload.ChainId,
+		Message:     payload.Message,
+		Nonce:       payload.Nonce,
+		Prefix:      SignedMessagePrefix,
+	}
+	if payload.IncludeAddress {
+		address := signer.SignerAddress()
+		r.Address = &address
+	}
+	r.FullMessage = newFullMessage(r)
+
+	signature, err := signer.SignRawData([]byte(r.FullMessage))
+	if err != nil {
+		return nil, err
+	}
+	r.Signature = signature
+
+	return r, nil
+}
+
+// parseFullMessage parses the full message into the fields of [SignedMessage].
+// The address is parsed, so the address in the message can be in short or long form.
+func parseFullMessage(fullMessage string) (*SignedMessage, error) {
+	r := &SignedMessage{FullMessage: fullMessage}
+
+	prefix, rest, _ := strings.Cut(fullMessage, "\n")
+	if prefix != SignedMessagePrefix {
+		return nil, fmt.Errorf("message doesn't start with %s", SignedMessagePrefix)
+	}
+	r.Prefix = prefix
+
+	if v, ok := cutLine(&rest, "address: "); ok {
+		address, err := ParseAddress(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse address %s: %w", v, err)
+		}
+		r.Address = &address
+	}
+	if v, ok := cutLine(&rest, "application: "); ok {
+		r.Application = v
+	}
+	if v, ok := cutLine(&rest, "chainId: "); ok {
+		chainId, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse chain id %s: %w", v, err)
+		}
+		r.ChainId = new(uint8)
+		*r.ChainId = uint8(chainId)
+	}
+
+	// message can contain new lines, and the nonce is the last line.
+	nonceIndex := strings.LastIndex(rest, "\nnonce: ")
+	if !strings.HasPrefix(rest, "message: ") || nonceIndex < 0 {
+		return nil, fmt.Errorf("missing message or nonce")
+	}
+	r.Message = rest[len("message: "):nonceIndex]
+	r.Nonce = rest[nonceIndex+len("\nnonce: "):]
+
+	return r, nil
+}
+
+// cutLine removes the first line from rest if the line starts with the key, and returns the value after the key.
+func cutLine(rest *string, key string) (string, bool) {
+	if !strings.HasPrefix(*rest, key) {
+		return "", false
+	}
+
+	line, after, _ := strings.Cut(*rest, "\n")
+	*rest = after
+
+	return line[len(key):], true
+}
+
+// Verify checks that the full message matches the fields, and the signature is valid.
+// The authentication key calculated from the public keys in the signature is returned,
+// which must be checked against the authentication key of the account, see [Client.VerifyMessage].
+func (m *SignedMessage) Verify() (Address, error) {
+	parsed, err := parseFullMessage(m.FullMessage)
+	if err != nil {
+		return Address{}, err
+	}
+
+	switch {
+	case (parsed.Address == nil) != (m.Address == nil) || (m.Address != nil && *parsed.Address != *m.Address):
+		return Address{}, fmt.Errorf("address doesn't match the full message")
+	case parsed.Application != m.Application:
+		return Address{}, fmt.Errorf("application doesn't match the full message")
+	case (parsed.ChainId == nil) != (m.ChainId == nil) || (m.ChainId != nil && *parsed.ChainId != *m.ChainId):
+		return Address{}, fmt.Errorf("chain id doesn't match the full message")
+	case parsed.Message != m.Message:
+		return Address{}, fmt.Errorf("message doesn't match the full message")
+	case parsed.Nonce != m.Nonce:
+		return Address{}, fmt.Errorf("nonce doesn't match the full message")
+	}
+
+	if m.Signature == nil {
+		return Address{}, fmt.Errorf("missing signature")
+	}
+	authenticator, err := NewAccountAuthenticator(m.Signature)
+	if err != nil {
+		return Address{}, err
+	}
+	if err := authenticator.Verify([]byte(m.FullMessage)); err != nil {
+		return Address{}, err
+	}
+
+	return authenticator.AuthenticationKey()
+}
+
+// VerifyMessage checks the signed message is signed by the current key of the account at the address.
+//
+// The message and the signature are verified by [SignedMessage.Verify], and the authentication key of the signature must be
+// the authentication key of the account on chain, which differs from the address if the key of the account has been rotated.
+// If the address is included in the message, it must be the same as the address.
+// The caller should check the nonce, application, and chain id.
+func (client *Client) VerifyMessage(ctx context.Context, address Address, m *SignedMessage) error {
+	if m.Address != nil && *m.Address != address {
+		return fmt.Errorf("message is signed for %s, not %s", m.Address, address)
+	}
+
+	authKey, err := m.Verify()
+	if err != nil {
+		return err
+	}
+
+	account, err := client.GetAccount(ctx, &GetAccountRequest{Address: address})
+	if err != nil {
+		return err
+	}
+
+	onChainAuthKey, err := ParseAddress(account.Parsed.AuthenticationKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse authentication key %s: %w", account.Parsed.AuthenticationKey, err)
+	}
+	if onChainAuthKey != authKey {
+		return fmt.Errorf("message is signed by %s, but the authentication key of %s is %s", authKey, address, onChainAuthKey)
+	}
+
+	return nil
+}
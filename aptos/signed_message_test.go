@@ -0,0 +1,138 @@
+package aptos_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+)
+
+func TestSignMessage(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainId := uint8(2)
+
+	signed, err := aptos.SignMessage(account, &aptos.SignMessagePayload{
+		IncludeAddress: true,
+		Application:    "example.com",
+		ChainId:        &chainId,
+		Message:        "hello\nworld",
+		Nonce:          "1234",
+	})
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	want := fmt.Sprintf("APTOS\naddress: 0x%x\napplication: example.com\nchainId: 2\nmessage: hello\nworld\nnonce: 1234", account.Address[:])
+	if signed.FullMessage != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, signed.FullMessage)
+	}
+
+	// round trip through json, as the signed message is usually sent to the verifier.
+	jsonBytes, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := &aptos.SignedMessage{}
+	if err := json.Unmarshal(jsonBytes, received); err != nil {
+		t.Fatal(err)
+	}
+
+	authKey, err := received.Verify()
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+	if authKey != account.Address {
+		t.Fatalf("want auth key %s, got %s", account.Address, authKey)
+	}
+
+	tampered := *received
+	tampered.Nonce = "5678"
+	if _, err := tampered.Verify(); err == nil {
+		t.Fatalf("verify should fail with different nonce")
+	}
+
+	tampered = *received
+	tampered.FullMessage = want[:len(want)-1] + "5"
+	tampered.Nonce = "1235"
+	if _, err := tampered.Verify(); err == nil {
+		t.Fatalf("verify should fail with tampered full message")
+	}
+}
+
+func TestSignMessage_multiKey(t *testing.T) {
+	_, key0, _ := ed25519.GenerateKey(nil)
+	key1, err := aptos.GenerateSecp256k1PrivateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := aptos.NewMultiKeyAccount(1, aptos.Ed25519PrivateKey(key0).AnyPublicKey(), key1.AnyPublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := account.AddPrivateKey(key1); err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := aptos.SignMessage(account, &aptos.SignMessagePayload{Message: "hello", Nonce: "1"})
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if signed.FullMessage != "APTOS\nmessage: hello\nnonce: 1" {
+		t.Fatalf("wrong full message: %s", signed.FullMessage)
+	}
+
+	authKey, err := signed.Verify()
+	if err != nil || authKey != account.Address {
+		t.Fatalf("want auth key %s, got %s %v", account.Address, authKey, err)
+	}
+}
+
+func TestClient_VerifyMessage(t *testing.T) {
+	account, err := aptos.NewLocalAccountWithRandomKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated := aptos.MustParseAddress("0xb")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/" + account.Address.String():
+			fmt.Fprintf(w, `{"sequence_number": "0", "authentication_key": "0x%x"}`, account.Address[:])
+		case "/accounts/" + rotated.String():
+			fmt.Fprintf(w, `{"sequence_number": "0", "authentication_key": "0x%x"}`, rotated[:])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+
+	signed, err := aptos.SignMessage(account, &aptos.SignMessagePayload{Message: "hello", Nonce: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.VerifyMessage(context.Background(), account.Address, signed); err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+	if err := client.VerifyMessage(context.Background(), rotated, signed); err == nil {
+		t.Fatalf("verify should fail when the authentication key doesn't match")
+	}
+
+	withAddress, err := aptos.SignMessage(account, &aptos.SignMessagePayload{IncludeAddress: true, Message: "hello", Nonce: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.VerifyMessage(context.Background(), rotated, withAddress); err == nil {
+		t.Fatalf("verify should fail when the address doesn't match")
+	}
+}
@@ -0,0 +1,534 @@
+package aptos
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fardream/go-bcs/bcs"
+	"golang.org/x/crypto/sha3"
+)
+
+// variants of [TransactionAuthenticator] in bcs.
+const (
+	TransactionAuthenticatorVariant_Ed25519      = 0
+	TransactionAuthenticatorVariant_MultiEd25519 = 1
+	TransactionAuthenticatorVariant_MultiAgent   = 2
+	TransactionAuthenticatorVariant_FeePayer     = 3
+	TransactionAuthenticatorVariant_SingleSender = 4
+)
+
+// variants of [AccountAuthenticator] in bcs.
+const (
+	AccountAuthenticatorVariant_Ed25519                = 0
+	AccountAuthenticatorVariant_MultiEd25519           = 1
+	AccountAuthenticatorVariant_SingleKey              = 2
+	AccountAuthenticatorVariant_MultiKey               = 3
+	AccountAuthenticatorVariant_NoAccountAuthenticator = 4
+)
+
+// Ed25519Authenticator contains the public key and the signature of a single ed25519 signer.
+type Ed25519Authenticator struct {
+	PublicKey []byte
+	Signature []byte
+}
+
+// TransactionAuthenticator proves the transaction is authorized by the signers.
+// This is an enum, and only one of the fields should be set.
+//
+// See [TransactionAuthenticator in aptos-core].
+//
+// [TransactionAuthenticator in aptos-core]: https://github.com/aptos-labs/aptos-core/blob/main/types/src/transaction/authenticator.rs
+type TransactionAuthenticator struct {
+	Ed25519      *Ed25519Authenticator
+	MultiEd25519 *MultiEd25519Authenticator
+	MultiAgent   *MultiAgentAuthenticator
+	FeePayer     *FeePayerAuthenticator
+	SingleSender *AccountAuthenticator
+}
+
+var (
+	_ bcs.Marshaler   = (*TransactionAuthenticator)(nil)
+	_ bcs.Unmarshaler = (*TransactionAuthenticator)(nil)
+)
+
+// MarshalBCS encodes the variant and the authenticator.
+func (a TransactionAuthenticator) MarshalBCS() ([]byte, error) {
+	switch {
+	case a.Ed25519 != nil:
+		return marshalBcsEnumVariant(TransactionAuthenticatorVariant_Ed25519, a.Ed25519)
+	case a.MultiEd25519 != nil:
+		return marshalBcsEnumVariant(TransactionAuthenticatorVariant_MultiEd25519, a.MultiEd25519)
+	case a.MultiAgent != nil:
+		return marshalBcsEnumVariant(TransactionAuthenticatorVariant_MultiAgent, a.MultiAgent)
+	case a.FeePayer != nil:
+		return marshalBcsEnumVariant(TransactionAuthenticatorVariant_FeePayer, a.FeePayer)
+	case a.SingleSender != nil:
+		return marshalBcsEnumVariant(TransactionAuthenticatorVariant_SingleSender, a.SingleSender)
+	default:
+		return nil, fmt.Errorf("unset transaction authenticator")
+	}
+}
+
+func (a *TransactionAuthenticator) UnmarshalBCS(r io.Reader) (int, error) {
+	b := newBcsReader(r)
+	a.decode(b)
+
+	return b.n, b.err
+}
+
+func (a *TransactionAuthenticator) decode(b *bcsReader) {
+	variant := b.uleb128()
+	if b.err != nil {
+		return
+	}
+
+	switch variant {
+	case TransactionAuthenticatorVariant_Ed25519:
+		a.Ed25519 = &Ed25519Authenticator{}
+		b.decode(a.Ed25519)
+	case TransactionAuthenticatorVariant_MultiEd25519:
+		a.MultiEd25519 = &MultiEd25519Authenticator{}
+		b.decode(a.MultiEd25519)
+	case TransactionAuthenticatorVariant_MultiAgent:
+		a.MultiAgent = &MultiAgentAuthenticator{}
+		a.MultiAgent.decode(b)
+	case TransactionAuthenticatorVariant_FeePayer:
+		a.FeePayer = &FeePayerAuthenticator{}
+		a.FeePayer.decode(b)
+	case TransactionAuthenticatorVariant_SingleSender:
+		a.SingleSender = &AccountAuthenticator{}
+		a.SingleSender.decode(b)
+	default:
+		b.err = fmt.Errorf("unsupported transaction authenticator variant: %d", variant)
+	}
+}
+
+// accountAuthenticators returns all the account authenticators contained in the transaction authenticator.
+// Single ed25519 authenticator of the sender is converted to an account authenticator.
+func (a *TransactionAuthenticator) accountAuthenticators() []*AccountAuthenticator {
+	switch {
+	case a.Ed25519 != nil:
+		return []*AccountAuthenticator{{Ed25519: a.Ed25519}}
+	case a.MultiEd25519 != nil:
+		return []*AccountAuthenticator{{MultiEd25519: a.MultiEd25519}}
+	case a.MultiAgent != nil:
+		return append([]*AccountAuthenticator{a.MultiAgent.Sender}, a.MultiAgent.SecondarySigners...)
+	case a.FeePayer != nil:
+		r := append([]*AccountAuthenticator{a.FeePayer.Sender}, a.FeePayer.SecondarySigners...)
+		return append(r, a.FeePayer.FeePayerSigner)
+	case a.SingleSender != nil:
+		return []*AccountAuthenticator{a.SingleSender}
+	default:
+		return nil
+	}
+}
+
+// AccountAuthenticator proves a single account authorizes the transaction.
+// It is used for the signers of multi agent transactions.
+// This is an enum, and only one of the fields should be set.
+//
+// NoAccountAuthenticator is only valid for simulation, where the fee payer of a [FeePayerTransaction] doesn't sign.
+type AccountAuthenticator struct {
+	Ed25519                *Ed25519Authenticator
+	MultiEd25519           *MultiEd25519Authenticator
+	SingleKey              *SingleKeyAuthenticator
+	MultiKey               *MultiKeyAuthenticator
+	NoAccountAuthenticator *struct{}
+}
+
+var (
+	_ bcs.Marshaler   = (*AccountAuthenticator)(nil)
+	_ bcs.Unmarshaler = (*AccountAuthenticator)(nil)
+)
+
+// NewAccountAuthenticator creates an account authenticator from the signature returned by a [Signer] or [RawDataSigner].
+func NewAccountAuthenticator(signature *SingleSignature) (*AccountAuthenticator, error) {
+	switch signature.Type {
+	case SingleKeySignatureType:
+		if signature.SingleKey == nil {
+			return nil, fmt.Errorf("single key signature is not set")
+		}
+		return &AccountAuthenticator{SingleKey: signature.SingleKey}, nil
+	case MultiKeySignatureType:
+		if signature.MultiKey == nil {
+			return nil, fmt.Errorf("multi key signature is not set")
+		}
+		return &AccountAuthenticator{MultiKey: signature.MultiKey}, nil
+	case MultiEd25519SignatureType:
+		multiEd25519Authenticator, err := newMultiEd25519Authenticator(signature)
+		if err != nil {
+			return nil, err
+		}
+		return &AccountAuthenticator{MultiEd25519: multiEd25519Authenticator}, nil
+	default:
+		ed25519Authenticator, err := newEd25519Authenticator(signature)
+		if err != nil {
+			return nil, err
+		}
+		return &AccountAuthenticator{Ed25519: ed25519Authenticator}, nil
+	}
+}
+
+// MarshalBCS encodes the variant and the authenticator.
+func (a AccountAuthenticator) MarshalBCS() ([]byte, error) {
+	switch {
+	case a.Ed25519 != nil:
+		return marshalBcsEnumVariant(AccountAuthenticatorVariant_Ed25519, a.Ed25519)
+	case a.MultiEd25519 != nil:
+		return marshalBcsEnumVariant(AccountAuthenticatorVariant_MultiEd25519, a.MultiEd25519)
+	case a.SingleKey != nil:
+		return marshalBcsEnumVariant(AccountAuthenticatorVariant_SingleKey, a.SingleKey)
+	case a.MultiKey != nil:
+		return marshalBcsEnumVariant(AccountAuthenticatorVariant_MultiKey, a.MultiKey)
+	case a.NoAccountAuthenticator != nil:
+		return marshalBcsEnumVariant(AccountAuthenticatorVariant_NoAccountAuthenticator, a.NoAccountAuthenticator)
+	default:
+		return nil, fmt.Errorf("unset account authenticator")
+	}
+}
+
+func (a *AccountAuthenticator) UnmarshalBCS(r io.Reader) (int, error) {
+	b := newBcsReader(r)
+	a.decode(b)
+
+	return b.n, b.err
+}
+
+func (a *AccountAuthenticator) decode(b *bcsReader) {
+	variant := b.uleb128()
+	if b.err != nil {
+		return
+	}
+
+	switch variant {
+	case AccountAuthenticatorVariant_Ed25519:
+		a.Ed25519 = &Ed25519Authenticator{}
+		b.decode(a.Ed25519)
+	case AccountAuthenticatorVariant_MultiEd25519:
+		a.MultiEd25519 = &MultiEd25519Authenticator{}
+		b.decode(a.MultiEd25519)
+	case AccountAuthenticatorVariant_SingleKey:
+		a.SingleKey = &SingleKeyAuthenticator{}
+		a.SingleKey.decode(b)
+	case AccountAuthenticatorVariant_MultiKey:
+		a.MultiKey = &MultiKeyAuthenticator{}
+		a.MultiKey.decode(b)
+	case AccountAuthenticatorVariant_NoAccountAuthenticator:
+		a.NoAccountAuthenticator = &struct{}{}
+	default:
+		b.err = fmt.Errorf("unsupported account authenticator variant: %d", variant)
+	}
+}
+
+// isSimulation checks if the signature is invalid, as required by simulation.
+func (a *AccountAuthenticator) isSimulation() bool {
+	switch {
+	case a.Ed25519 != nil:
+		return prefixedHexString(a.Ed25519.Signature) == simulationSignature
+	case a.MultiEd25519 != nil:
+		return a.MultiEd25519.isSimulation()
+	case a.SingleKey != nil:
+		return a.SingleKey.Signature.isSimulation()
+	case a.MultiKey != nil:
+		return a.MultiKey.isSimulation()
+	default:
+		return true
+	}
+}
+
+// Verify checks the signatures of the authenticator against the message.
+// K-of-N authenticators must contain at least K valid signatures.
+func (a *AccountAuthenticator) Verify(message []byte) error {
+	switch {
+	case a.Ed25519 != nil:
+		if len(a.Ed25519.PublicKey) != ed25519.PublicKeySize || !ed25519.Verify(a.Ed25519.PublicKey, message, a.Ed25519.Signature) {
+			return fmt.Errorf("invalid ed25519 signature")
+		}
+		return nil
+	case a.MultiEd25519 != nil:
+		return a.MultiEd25519.verify(message)
+	case a.SingleKey != nil:
+		if a.SingleKey.PublicKey == nil || a.SingleKey.Signature == nil || !a.SingleKey.PublicKey.Verify(message, a.SingleKey.Signature) {
+			return fmt.Errorf("invalid single key signature")
+		}
+		return nil
+	case a.MultiKey != nil:
+		return a.MultiKey.verify(message)
+	default:
+		return fmt.Errorf("account authenticator has no signature")
+	}
+}
+
+// AuthenticationKey calculates the authentication key from the public keys of the authenticator.
+func (a *AccountAuthenticator) AuthenticationKey() (Address, error) {
+	switch {
+	case a.Ed25519 != nil:
+		return GenerateAuthenticationKey(1, 1, a.Ed25519.PublicKey)
+	case a.MultiEd25519 != nil:
+		return sha3.Sum256(append(append([]byte{}, a.MultiEd25519.PublicKey...), 1)), nil
+	case a.SingleKey != nil && a.SingleKey.PublicKey != nil:
+		return GenerateSingleKeyAuthenticationKey(a.SingleKey.PublicKey)
+	case a.MultiKey != nil && a.MultiKey.PublicKeys != nil:
+		return GenerateMultiKeyAuthenticationKey(int(a.MultiKey.PublicKeys.SignaturesRequired), a.MultiKey.PublicKeys.PublicKeys...)
+	default:
+		return Address{}, fmt.Errorf("account authenticator has no public key")
+	}
+}
+
+// marshalBcsEnumVariant encodes the enum variant followed by the value.
+func marshalBcsEnumVariant(variant int, v any) ([]byte, error) {
+	data, err := bcs.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(bcs.ULEB128Encode(variant), data...), nil
+}
+
+// newEd25519Authenticator parses the public key and signature of a single ed25519 signature.
+func newEd25519Authenticator(signature *SingleSignature) (*Ed25519Authenticator, error) {
+	if signature.Type != Ed25519SignatureType {
+		return nil, fmt.Errorf("unsupported signature type: %s", signature.Type)
+	}
+
+	publicKey, err := parseHexString(signature.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", signature.PublicKey, err)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key %s is not length %d", signature.PublicKey, ed25519.PublicKeySize)
+	}
+
+	sig, err := parseHexString(signature.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature %s: %w", signature.Signature, err)
+	}
+	if len(sig) != SignatureLength {
+		return nil, fmt.Errorf("signature %s is not length %d", signature.Signature, SignatureLength)
+	}
+
+	return &Ed25519Authenticator{PublicKey: publicKey, Signature: sig}, nil
+}
+
+// NewTransactionAuthenticator creates an authenticator from the signature returned by a [Signer].
+// Single key and multi key signatures are wrapped in a single sender authenticator.
+func NewTransactionAuthenticator(signature *SingleSignature) (*TransactionAuthenticator, error) {
+	accountAuthenticator, err := NewAccountAuthenticator(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case accountAuthenticator.Ed25519 != nil:
+		return &TransactionAuthenticator{Ed25519: accountAuthenticator.Ed25519}, nil
+	case accountAuthenticator.MultiEd25519 != nil:
+		return &TransactionAuthenticator{MultiEd25519: accountAuthenticator.MultiEd25519}, nil
+	default:
+		return &TransactionAuthenticator{SingleSender: accountAuthenticator}, nil
+	}
+}
+
+// SignedTransaction is a raw transaction with its authenticator, which can be submitted to the chain in bcs.
+//
+// Unlike [SubmitTransactionRequest], which relies on the node to convert json to bcs,
+// the signed transaction is encoded locally and can carry arguments that are difficult to express in json.
+type SignedTransaction struct {
+	*Transaction
+	Authenticator *TransactionAuthenticator
+}
+
+var (
+	_ bcs.Marshaler   = (*SignedTransaction)(nil)
+	_ bcs.Unmarshaler = (*SignedTransaction)(nil)
+)
+
+// NewSignedTransaction creates a signed transaction from the transaction and the signature returned by a [Signer].
+func NewSignedTransaction(tx *Transaction, signature *SingleSignature) (*SignedTransaction, error) {
+	authenticator, err := NewTransactionAuthenticator(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTransaction{Transaction: tx, Authenticator: authenticator}, nil
+}
+
+// SignTransaction signs the transaction with the signer and creates a [SignedTransaction].
+func SignTransaction(signer Signer, tx *Transaction) (*SignedTransaction, error) {
+	signature, err := signer.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignedTransaction(tx, signature)
+}
+
+// SignTransactionForSimulation creates a [SignedTransaction] with an invalid signature for [Client.SimulateSignedTransactionBCS].
+func SignTransactionForSimulation(signer Signer, tx *Transaction) (*SignedTransaction, error) {
+	signature, err := signer.SignForSimulation(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignedTransaction(tx, signature)
+}
+
+// MarshalBCS encodes the raw transaction followed by the authenticator.
+func (tx SignedTransaction) MarshalBCS() ([]byte, error) {
+	if tx.Transaction == nil || tx.Authenticator == nil {
+		return nil, fmt.Errorf("transaction or authenticator is missing")
+	}
+
+	txBytes, err := bcs.Marshal(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	authBytes, err := tx.Authenticator.MarshalBCS()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(txBytes, authBytes...), nil
+}
+
+// signedTransactionPrefix is sha3-256 of "APTOS::Transaction"
+var signedTransactionPrefix []byte = sha3_Sum256Slice([]byte("APTOS::Transaction"))
+
+// transactionVariant_UserTransaction is the variant of user transaction in the Transaction enum of aptos-core,
+// which also includes genesis and block metadata transactions.
+const transactionVariant_UserTransaction = 0
+
+// GetHash calculates the hash of the signed transaction, which is the same as the hash reported by the chain.
+// The hash is known before the transaction is submitted, and can be used to track the transaction.
+//
+// Hash of the transaction is sha3-256 of (sha3-256 of "APTOS::Transaction" | 0x00 | bcs encoded signed transaction),
+// where 0x00 is the variant of user transaction. See [GetTransactionByHash].
+func (tx *SignedTransaction) GetHash() ([]byte, error) {
+	txBytes, err := tx.MarshalBCS()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(signedTransactionPrefix)+1+len(txBytes))
+	data = append(data, signedTransactionPrefix...)
+	data = append(data, transactionVariant_UserTransaction)
+	data = append(data, txBytes...)
+
+	return sha3_Sum256Slice(data), nil
+}
+
+// GetHashString is [SignedTransaction.GetHash] as 0x prefixed hex string.
+func (tx *SignedTransaction) GetHashString() (string, error) {
+	hash, err := tx.GetHash()
+	if err != nil {
+		return "", err
+	}
+
+	return prefixedHexString(hash), nil
+}
+
+func (tx *SignedTransaction) UnmarshalBCS(r io.Reader) (int, error) {
+	b := newBcsReader(r)
+	tx.Transaction = &Transaction{}
+	tx.Transaction.decode(b)
+	tx.Authenticator = &TransactionAuthenticator{}
+	tx.Authenticator.decode(b)
+
+	return b.n, b.err
+}
+
+// SubmitSignedTransactionBCS submits the bcs encoded signed transaction to [SubmitTransaction] endpoint.
+// Retry works the same as [Client.SubmitTransaction].
+//
+// [SubmitTransaction]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/operations/submit_transaction
+func (client *Client) SubmitSignedTransactionBCS(ctx context.Context, tx *SignedTransaction) (*AptosResponse[SubmitTransactionResponse], error) {
+	hash, err := tx.GetHashString()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.submitTransaction(ctx, &SubmitSignedTransactionBCSRequest{SignedTransaction: tx}, hash)
+}
+
+// SubmitSignedTransactionBCSRequest posts the [SignedTransaction] in bcs.
+type SubmitSignedTransactionBCSRequest struct {
+	*SignedTransaction `url:"-"`
+}
+
+var (
+	_ AptosRequest       = (*SubmitSignedTransactionBCSRequest)(nil)
+	_ ContentTypeRequest = (*SubmitSignedTransactionBCSRequest)(nil)
+)
+
+func (r *SubmitSignedTransactionBCSRequest) Body() ([]byte, error) {
+	return r.SignedTransaction.MarshalBCS()
+}
+
+func (r *SubmitSignedTransactionBCSRequest) HttpMethod() string {
+	return http.MethodPost
+}
+
+func (r *SubmitSignedTransactionBCSRequest) PathSegments() ([]string, error) {
+	return []string{"transactions"}, nil
+}
+
+func (r *SubmitSignedTransactionBCSRequest) ContentType() string {
+	return ContentTypeSignedTransactionBcs
+}
+
+// SimulateSignedTransactionBCS simulates the bcs encoded signed transaction with [SimulateTransaction] endpoint.
+// The signature must be invalid, see [SignTransactionForSimulation].
+//
+// [SimulateTransaction]: https://fullnode.mainnet.aptoslabs.com/v1/transactions/simulate
+func (client *Client) SimulateSignedTransactionBCS(ctx context.Context, request *SimulateSignedTransactionBCSRequest) (*AptosResponse[SimulateTransactionResponse], error) {
+	if request.SignedTransaction == nil || request.Authenticator == nil {
+		return nil, fmt.Errorf("transaction or authenticator is missing")
+	}
+	for _, auth := range request.Authenticator.accountAuthenticators() {
+		if !auth.isSimulation() {
+			return nil, fmt.Errorf("signatures must be invalid for simulation")
+		}
+	}
+
+	return doRequestForType[SimulateTransactionResponse](ctx, client, request)
+}
+
+// SimulateSignedTransactionBCSRequest posts the [SignedTransaction] in bcs for simulation.
+type SimulateSignedTransactionBCSRequest struct {
+	*SignedTransaction `url:"-"`
+
+	// EstimateGasUnitPrice uses the estimated gas unit price for the simulation.
+	EstimateGasUnitPrice bool `url:"estimate_gas_unit_price,omitempty"`
+	// EstimateMaxGasAmount uses the max gas amount affordable by the sender for the simulation.
+	EstimateMaxGasAmount bool `url:"estimate_max_gas_amount,omitempty"`
+	// EstimatePrioritizedGasUnitPrice uses the prioritized gas unit price for the simulation.
+	EstimatePrioritizedGasUnitPrice bool `url:"estimate_prioritized_gas_unit_price,omitempty"`
+}
+
+var (
+	_ AptosRequest       = (*SimulateSignedTransactionBCSRequest)(nil)
+	_ ContentTypeRequest = (*SimulateSignedTransactionBCSRequest)(nil)
+)
+
+func (r *SimulateSignedTransactionBCSRequest) Body() ([]byte, error) {
+	return r.SignedTransaction.MarshalBCS()
+}
+
+func (r *SimulateSignedTransactionBCSRequest) HttpMethod() string {
+	return http.MethodPost
+}
+
+func (r *SimulateSignedTransactionBCSRequest) PathSegments() ([]string, error) {
+	return []string{"transactions", "simulate"}, nil
+}
+
+func (r *SimulateSignedTransactionBCSRequest) ContentType() string {
+	return ContentTypeSignedTransactionBcs
+}
+
+func (r *SimulateSignedTransactionBCSRequest) IsIdempotent() bool {
+	return true
+}
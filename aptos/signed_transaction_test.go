@@ -0,0 +1,126 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// testSignedTransaction1 is the transaction in test_data/test_tx_1.json.
+func testSignedTransaction1(t *testing.T) *aptos.SignedTransaction {
+	t.Helper()
+
+	sender := aptos.MustParseAddress("0x767b7442b8547fa5cf50989b9b761760ca6687b83d1c23d3589a5ac8acb50639")
+	config, _ := aptos.GetAuxClientConfig(aptos.Devnet)
+	moduleAddress := aptos.MustParseAddress("0xea383dc2819210e6e427e66b2b6aa064435bf672dc4bdc55018049f0c361d01a")
+	aux, _ := aptos.GetAuxFakeCoinCoinType(moduleAddress, aptos.AuxFakeCoin_AUX)
+	usdc, _ := aptos.GetAuxFakeCoinCoinType(moduleAddress, aptos.AuxFakeCoin_USDC)
+	tx := config.ClobMarket_PlaceOrder(
+		sender,
+		true,
+		aux,
+		usdc,
+		50000000,
+		50000000000,
+		0,
+		bcs.Uint128{},
+		aptos.AuxClobMarketOrderType_Limit,
+		0,
+		false,
+		18446744073709551615,
+		aptos.AuxClobMarketSelfTradeType_CancelPassive,
+		aptos.TransactionOption_GasUnitPrice(100),
+		aptos.TransactionOption_SequenceNumber(2625),
+		aptos.TransactionOption_MaxGasAmount(1718192),
+	)
+
+	tx.ExpirationTimestampSecs = 1667245112
+	tx.ChainId = 35
+
+	signedTx, err := aptos.NewSignedTransaction(tx, &aptos.SingleSignature{
+		Type:      aptos.Ed25519SignatureType,
+		PublicKey: "0xff0ef0e5910c05c24551f135485a1fcb3bced53e20996bd26bfc23aa8816756c",
+		Signature: "0x05c4586e2b47fa4d81a2fab4b6ac7975fb4d2a410d507bada48b8a0adff14c1dd18a9c8342076a133e7f6b358efba0bff777c3cc78f1131f5c5d617329b8200a",
+	})
+	if err != nil {
+		t.Fatalf("failed to create signed transaction: %v", err)
+	}
+
+	return signedTx
+}
+
+func TestSignedTransaction_RoundTrip(t *testing.T) {
+	signedTx := testSignedTransaction1(t)
+
+	encoded, err := bcs.Marshal(signedTx)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	var decoded aptos.SignedTransaction
+	n, err := bcs.Unmarshal(encoded, &decoded)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if n != len(encoded) {
+		t.Fatalf("want %d bytes consumed, got %d", len(encoded), n)
+	}
+
+	if decoded.Sender != signedTx.Sender || decoded.SequenceNumber != 2625 || decoded.ChainId != 35 || decoded.ExpirationTimestampSecs != 1667245112 {
+		t.Fatalf("wrong transaction decoded: %#v", decoded.Transaction)
+	}
+	if decoded.Payload.Function.String() != signedTx.Payload.Function.String() {
+		t.Fatalf("want function %s, got %s", signedTx.Payload.Function, decoded.Payload.Function)
+	}
+	if len(decoded.Payload.TypeArguments) != 2 || decoded.Payload.TypeArguments[1].String() != signedTx.Payload.TypeArguments[1].String() {
+		t.Fatalf("wrong type arguments: %v", decoded.Payload.TypeArguments)
+	}
+	if !bytes.Equal(decoded.Authenticator.Ed25519.Signature, signedTx.Authenticator.Ed25519.Signature) {
+		t.Fatalf("wrong signature decoded")
+	}
+
+	reEncoded, err := bcs.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("failed to encode decoded transaction: %v", err)
+	}
+	if !bytes.Equal(encoded, reEncoded) {
+		t.Fatalf("round trip changes the encoding:\nwant: %x\ngot:  %x", encoded, reEncoded)
+	}
+}
+
+func TestClient_SubmitSignedTransactionBCS(t *testing.T) {
+	signedTx := testSignedTransaction1(t)
+	want := bcs.MustMarshal(signedTx)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != aptos.ContentTypeSignedTransactionBcs {
+			t.Errorf("wrong content type: %s", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !bytes.Equal(body, want) {
+			t.Errorf("wrong body:\nwant: %x\ngot:  %x", want, body)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"type": "pending_transaction", "hash": "0xb161e7592d5f8ea8a97f3493669660205ee76f8699f20e71ae2ad3878836a1ac"}`))
+	}))
+	defer server.Close()
+
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	resp, err := client.SubmitSignedTransactionBCS(context.Background(), signedTx)
+	if err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+	if resp.Parsed.Hash != "0xb161e7592d5f8ea8a97f3493669660205ee76f8699f20e71ae2ad3878836a1ac" {
+		t.Fatalf("wrong hash: %s", resp.Parsed.Hash)
+	}
+
+	if _, err := client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{SignedTransaction: signedTx}); err == nil {
+		t.Fatalf("simulation with valid signature should fail")
+	}
+}
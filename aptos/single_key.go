@@ -0,0 +1,347 @@
+package aptos
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// variants of [AnyPublicKey] and [AnySignature] in bcs.
+const (
+	AnyKeyVariant_Ed25519        = 0
+	AnyKeyVariant_Secp256k1Ecdsa = 1
+)
+
+// types of [AnyPublicKey] and [AnySignature] in json.
+const (
+	anyKeyTypeStr_Ed25519        = "ed25519"
+	anyKeyTypeStr_Secp256k1Ecdsa = "secp256k1_ecdsa"
+)
+
+// AnyPublicKey is a public key of any scheme supported by [SingleKeyAccount] and [MultiKeyAccount].
+// This is an enum, and only one of the fields should be set.
+type AnyPublicKey struct {
+	Ed25519        ed25519.PublicKey
+	Secp256k1Ecdsa Secp256k1PublicKey
+}
+
+var (
+	_ bcs.Marshaler    = (*AnyPublicKey)(nil)
+	_ json.Marshaler   = (*AnyPublicKey)(nil)
+	_ json.Unmarshaler = (*AnyPublicKey)(nil)
+)
+
+// MarshalBCS encodes the variant and the public key bytes.
+func (k AnyPublicKey) MarshalBCS() ([]byte, error) {
+	switch {
+	case k.Ed25519 != nil:
+		return marshalBcsEnumVariant(AnyKeyVariant_Ed25519, []byte(k.Ed25519))
+	case k.Secp256k1Ecdsa != nil:
+		return marshalBcsEnumVariant(AnyKeyVariant_Secp256k1Ecdsa, []byte(k.Secp256k1Ecdsa))
+	default:
+		return nil, fmt.Errorf("unset public key")
+	}
+}
+
+func (k *AnyPublicKey) decode(b *bcsReader) {
+	variant := b.uleb128()
+	if b.err != nil {
+		return
+	}
+
+	switch variant {
+	case AnyKeyVariant_Ed25519:
+		k.Ed25519 = b.bytes()
+	case AnyKeyVariant_Secp256k1Ecdsa:
+		k.Secp256k1Ecdsa = b.bytes()
+	default:
+		b.err = fmt.Errorf("unsupported public key variant: %d", variant)
+	}
+}
+
+func (k AnyPublicKey) MarshalJSON() ([]byte, error) {
+	switch {
+	case k.Ed25519 != nil:
+		return json.Marshal(anyKeyJson{Type: anyKeyTypeStr_Ed25519, Value: MoveBytecode(k.Ed25519)})
+	case k.Secp256k1Ecdsa != nil:
+		return json.Marshal(anyKeyJson{Type: anyKeyTypeStr_Secp256k1Ecdsa, Value: MoveBytecode(k.Secp256k1Ecdsa)})
+	default:
+		return nil, fmt.Errorf("unset public key")
+	}
+}
+
+func (k *AnyPublicKey) UnmarshalJSON(data []byte) error {
+	var v anyKeyJson
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*k = AnyPublicKey{}
+	switch v.Type {
+	case anyKeyTypeStr_Ed25519:
+		k.Ed25519 = ed25519.PublicKey(v.Value)
+	case anyKeyTypeStr_Secp256k1Ecdsa:
+		k.Secp256k1Ecdsa = Secp256k1PublicKey(v.Value)
+	default:
+		return fmt.Errorf("unsupported public key type: %s", v.Type)
+	}
+
+	return nil
+}
+
+// Verify checks the signature of the message, the signature must be of the same scheme as the public key.
+func (k *AnyPublicKey) Verify(message []byte, signature *AnySignature) bool {
+	switch {
+	case k.Ed25519 != nil && signature.Ed25519 != nil:
+		return len(k.Ed25519) == ed25519.PublicKeySize && ed25519.Verify(k.Ed25519, message, signature.Ed25519)
+	case k.Secp256k1Ecdsa != nil && signature.Secp256k1Ecdsa != nil:
+		return k.Secp256k1Ecdsa.Verify(message, signature.Secp256k1Ecdsa)
+	default:
+		return false
+	}
+}
+
+// Equal checks if the two public keys are the same.
+func (k *AnyPublicKey) Equal(other *AnyPublicKey) bool {
+	a, errA := bcs.Marshal(k)
+	b, errB := bcs.Marshal(other)
+
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// simulationSignature returns an all zero signature of the scheme of the public key.
+func (k *AnyPublicKey) simulationSignature() *AnySignature {
+	switch {
+	case k.Secp256k1Ecdsa != nil:
+		return &AnySignature{Secp256k1Ecdsa: make([]byte, Secp256k1SignatureLength)}
+	default:
+		return &AnySignature{Ed25519: make([]byte, SignatureLength)}
+	}
+}
+
+// AnySignature is a signature of any scheme supported by [SingleKeyAccount] and [MultiKeyAccount].
+// This is an enum, and only one of the fields should be set.
+type AnySignature struct {
+	Ed25519        []byte
+	Secp256k1Ecdsa []byte
+}
+
+var (
+	_ bcs.Marshaler    = (*AnySignature)(nil)
+	_ json.Marshaler   = (*AnySignature)(nil)
+	_ json.Unmarshaler = (*AnySignature)(nil)
+)
+
+// MarshalBCS encodes the variant and the signature bytes.
+func (s AnySignature) MarshalBCS() ([]byte, error) {
+	switch {
+	case s.Ed25519 != nil:
+		return marshalBcsEnumVariant(AnyKeyVariant_Ed25519, s.Ed25519)
+	case s.Secp256k1Ecdsa != nil:
+		return marshalBcsEnumVariant(AnyKeyVariant_Secp256k1Ecdsa, s.Secp256k1Ecdsa)
+	default:
+		return nil, fmt.Errorf("unset signature")
+	}
+}
+
+func (s *AnySignature) decode(b *bcsReader) {
+	variant := b.uleb128()
+	if b.err != nil {
+		return
+	}
+
+	switch variant {
+	case AnyKeyVariant_Ed25519:
+		s.Ed25519 = b.bytes()
+	case AnyKeyVariant_Secp256k1Ecdsa:
+		s.Secp256k1Ecdsa = b.bytes()
+	default:
+		b.err = fmt.Errorf("unsupported signature variant: %d", variant)
+	}
+}
+
+func (s AnySignature) MarshalJSON() ([]byte, error) {
+	switch {
+	case s.Ed25519 != nil:
+		return json.Marshal(anyKeyJson{Type: anyKeyTypeStr_Ed25519, Value: s.Ed25519})
+	case s.Secp256k1Ecdsa != nil:
+		return json.Marshal(anyKeyJson{Type: anyKeyTypeStr_Secp256k1Ecdsa, Value: s.Secp256k1Ecdsa})
+	default:
+		return nil, fmt.Errorf("unset signature")
+	}
+}
+
+func (s *AnySignature) UnmarshalJSON(data []byte) error {
+	var v anyKeyJson
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*s = AnySignature{}
+	switch v.Type {
+	case anyKeyTypeStr_Ed25519:
+		s.Ed25519 = v.Value
+	case anyKeyTypeStr_Secp256k1Ecdsa:
+		s.Secp256k1Ecdsa = v.Value
+	default:
+		return fmt.Errorf("unsupported signature type: %s", v.Type)
+	}
+
+	return nil
+}
+
+// isSimulation checks if the signature is all zero.
+func (s *AnySignature) isSimulation() bool {
+	for _, v := range append(append([]byte{}, s.Ed25519...), s.Secp256k1Ecdsa...) {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// anyKeyJson is the json format of [AnyPublicKey] and [AnySignature].
+type anyKeyJson struct {
+	Type  string       `json:"type"`
+	Value MoveBytecode `json:"value"`
+}
+
+// PrivateKey signs messages for [SingleKeyAccount] and [MultiKeyAccount].
+// [Ed25519PrivateKey] and [Secp256k1PrivateKey] implement this interface.
+type PrivateKey interface {
+	AnyPublicKey() *AnyPublicKey
+	SignMessage(message []byte) (*AnySignature, error)
+}
+
+// Ed25519PrivateKey is an ed25519 private key used by [SingleKeyAccount] or [MultiKeyAccount].
+type Ed25519PrivateKey ed25519.PrivateKey
+
+var (
+	_ PrivateKey = (Ed25519PrivateKey)(nil)
+	_ PrivateKey = (Secp256k1PrivateKey)(nil)
+)
+
+func (key Ed25519PrivateKey) AnyPublicKey() *AnyPublicKey {
+	return &AnyPublicKey{Ed25519: ed25519.PrivateKey(key).Public().(ed25519.PublicKey)}
+}
+
+func (key Ed25519PrivateKey) SignMessage(message []byte) (*AnySignature, error) {
+	return &AnySignature{Ed25519: ed25519.Sign(ed25519.PrivateKey(key), message)}, nil
+}
+
+func (key Secp256k1PrivateKey) AnyPublicKey() *AnyPublicKey {
+	return &AnyPublicKey{Secp256k1Ecdsa: key.PublicKey()}
+}
+
+func (key Secp256k1PrivateKey) SignMessage(message []byte) (*AnySignature, error) {
+	signature, err := key.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnySignature{Secp256k1Ecdsa: signature}, nil
+}
+
+// SingleKeyAuthenticator contains the public key and the signature of a [SingleKeyAccount].
+type SingleKeyAuthenticator struct {
+	PublicKey *AnyPublicKey `json:"public_key"`
+	Signature *AnySignature `json:"signature"`
+}
+
+var _ bcs.Marshaler = (*SingleKeyAuthenticator)(nil)
+
+func (a SingleKeyAuthenticator) MarshalBCS() ([]byte, error) {
+	publicKey, err := bcs.Marshal(a.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := bcs.Marshal(a.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(publicKey, signature...), nil
+}
+
+func (a *SingleKeyAuthenticator) decode(b *bcsReader) {
+	a.PublicKey = &AnyPublicKey{}
+	a.PublicKey.decode(b)
+	a.Signature = &AnySignature{}
+	a.Signature.decode(b)
+}
+
+// SingleKeyAccount is an account with a single key of any scheme, see [PrivateKey].
+// The address is the authentication key calculated by [GenerateSingleKeyAuthenticationKey].
+type SingleKeyAccount struct {
+	PrivateKey PrivateKey
+	Address    Address
+}
+
+var (
+	_ Signer        = (*SingleKeyAccount)(nil)
+	_ RawDataSigner = (*SingleKeyAccount)(nil)
+)
+
+// NewSingleKeyAccount creates a single key account from the private key.
+func NewSingleKeyAccount(privateKey PrivateKey) (*SingleKeyAccount, error) {
+	address, err := GenerateSingleKeyAuthenticationKey(privateKey.AnyPublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &SingleKeyAccount{
+		PrivateKey: privateKey,
+		Address:    address,
+	}, nil
+}
+
+func (account *SingleKeyAccount) Sign(tx *Transaction) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &account.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	return account.SignRawData(EncodeTransaction(tx))
+}
+
+func (account *SingleKeyAccount) SignForSimulation(tx *Transaction) (*SingleSignature, error) {
+	if !IsAddressEqual(&tx.Sender, &account.Address) {
+		return nil, fmt.Errorf("can only sign for self")
+	}
+
+	return account.SignRawDataForSimulation(EncodeTransaction(tx))
+}
+
+func (account *SingleKeyAccount) SignerAddress() Address {
+	return account.Address
+}
+
+func (account *SingleKeyAccount) SignRawData(message []byte) (*SingleSignature, error) {
+	signature, err := account.PrivateKey.SignMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSingleKeySignature(account.PrivateKey.AnyPublicKey(), signature), nil
+}
+
+// SignRawDataForSimulation returns an all zero signature of the scheme of the key.
+func (account *SingleKeyAccount) SignRawDataForSimulation(message []byte) (*SingleSignature, error) {
+	publicKey := account.PrivateKey.AnyPublicKey()
+
+	return NewSingleKeySignature(publicKey, publicKey.simulationSignature()), nil
+}
+
+// NewSingleKeySignature creates a [SingleKeySignatureType] signature.
+func NewSingleKeySignature(publicKey *AnyPublicKey, signature *AnySignature) *SingleSignature {
+	return &SingleSignature{
+		Type: SingleKeySignatureType,
+		SingleKey: &SingleKeyAuthenticator{
+			PublicKey: publicKey,
+			Signature: signature,
+		},
+	}
+}
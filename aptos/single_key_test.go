@@ -0,0 +1,170 @@
+package aptos_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fardream/go-aptos/aptos"
+	"github.com/fardream/go-bcs/bcs"
+)
+
+func testSignAndRoundTrip(t *testing.T, signer aptos.Signer) *aptos.SignedTransaction {
+	t.Helper()
+
+	tx, _, _ := testMultiAgentTransaction(t)
+	tx.Sender = signer.SignerAddress()
+
+	signature, err := signer.Sign(tx.Transaction)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	jsonData, err := json.Marshal(signature)
+	if err != nil {
+		t.Fatalf("failed to marshal signature to json: %v", err)
+	}
+	var fromJson aptos.SingleSignature
+	if err := json.Unmarshal(jsonData, &fromJson); err != nil {
+		t.Fatalf("failed to unmarshal signature %s: %v", string(jsonData), err)
+	}
+	if reMarshaled, _ := json.Marshal(&fromJson); !bytes.Equal(jsonData, reMarshaled) {
+		t.Fatalf("json round trip changes the signature:\nwant: %s\ngot:  %s", jsonData, reMarshaled)
+	}
+
+	signedTx, err := aptos.NewSignedTransaction(tx.Transaction, signature)
+	if err != nil {
+		t.Fatalf("failed to create signed transaction: %v", err)
+	}
+
+	encoded := bcs.MustMarshal(signedTx)
+	var decoded aptos.SignedTransaction
+	if _, err := bcs.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if reEncoded := bcs.MustMarshal(&decoded); !bytes.Equal(encoded, reEncoded) {
+		t.Fatalf("round trip changes the encoding:\nwant: %x\ngot:  %x", encoded, reEncoded)
+	}
+
+	simulationTx, err := aptos.SignTransactionForSimulation(signer, tx.Transaction)
+	if err != nil {
+		t.Fatalf("failed to sign for simulation: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"type": "user_transaction", "hash": "0x01", "success": true}]`))
+	}))
+	defer server.Close()
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	if _, err := client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{SignedTransaction: simulationTx}); err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+	if _, err := client.SimulateSignedTransactionBCS(context.Background(), &aptos.SimulateSignedTransactionBCSRequest{SignedTransaction: signedTx}); err == nil {
+		t.Fatalf("simulation with valid signature should fail")
+	}
+
+	return signedTx
+}
+
+// testSubmitJSON submits the transaction signed by the signer in json, and returns the signature in the request.
+func testSubmitJSON(t *testing.T, signer aptos.Signer) map[string]any {
+	t.Helper()
+
+	var submitted struct {
+		Signature map[string]any `json:"signature"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&submitted); err != nil {
+			t.Errorf("failed to decode submitted transaction: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"type": "pending_transaction", "hash": "0x01"}`))
+	}))
+	defer server.Close()
+
+	tx, _, _ := testMultiAgentTransaction(t)
+	tx.Sender = signer.SignerAddress()
+	client := aptos.MustNewClient(aptos.Localnet, server.URL)
+	if _, err := client.SignSubmitTransactionWait(context.Background(), signer, tx.Transaction, true); err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+
+	if submitted.Signature["type"] != aptos.SingleSenderSignatureType {
+		t.Fatalf("want %s signature, got %v", aptos.SingleSenderSignatureType, submitted.Signature)
+	}
+
+	return submitted.Signature
+}
+
+func TestSingleKeyAccount(t *testing.T) {
+	_, ed25519Key, _ := ed25519.GenerateKey(nil)
+
+	for _, key := range []aptos.PrivateKey{testSecp256k1Key(t), aptos.Ed25519PrivateKey(ed25519Key)} {
+		account, err := aptos.NewSingleKeyAccount(key)
+		if err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+
+		signedTx := testSignAndRoundTrip(t, account)
+
+		auth := signedTx.Authenticator.SingleSender
+		if auth == nil || auth.SingleKey == nil {
+			t.Fatalf("wrong authenticator: %#v", signedTx.Authenticator)
+		}
+		if !auth.SingleKey.PublicKey.Verify(aptos.EncodeTransaction(signedTx.Transaction), auth.SingleKey.Signature) {
+			t.Fatalf("failed to verify signature")
+		}
+
+		signature := testSubmitJSON(t, account)
+		publicKey, _ := signature["public_key"].(map[string]any)
+		anyPublicKey := key.AnyPublicKey()
+		if _, ok := signature["signature"].(map[string]any); !ok || publicKey["value"] != fmt.Sprintf("0x%x", append(anyPublicKey.Ed25519, anyPublicKey.Secp256k1Ecdsa...)) {
+			t.Fatalf("wrong submitted signature: %v", signature)
+		}
+	}
+}
+
+func TestMultiKeyAccount(t *testing.T) {
+	_, ed25519Key, _ := ed25519.GenerateKey(nil)
+	secp256k1Key, err := aptos.GenerateSecp256k1PrivateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []aptos.PrivateKey{testSecp256k1Key(t), aptos.Ed25519PrivateKey(ed25519Key), secp256k1Key}
+
+	account, err := aptos.NewMultiKeyAccount(2, keys[0].AnyPublicKey(), keys[1].AnyPublicKey(), keys[2].AnyPublicKey())
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := account.AddPrivateKey(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := account.SignRawData([]byte("hello")); err == nil {
+		t.Fatalf("signing without enough keys should fail")
+	}
+	if err := account.AddPrivateKey(keys[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	signedTx := testSignAndRoundTrip(t, account)
+
+	auth := signedTx.Authenticator.SingleSender.MultiKey
+	if auth == nil || !bytes.Equal(auth.SignaturesBitmap, []byte{0xa0, 0, 0, 0}) {
+		t.Fatalf("wrong authenticator: %#v", signedTx.Authenticator)
+	}
+	message := aptos.EncodeTransaction(signedTx.Transaction)
+	if !keys[0].AnyPublicKey().Verify(message, auth.Signatures[0]) || !keys[2].AnyPublicKey().Verify(message, auth.Signatures[1]) {
+		t.Fatalf("failed to verify signatures")
+	}
+
+	signature := testSubmitJSON(t, account)
+	publicKeys, _ := signature["public_keys"].([]any)
+	signatures, _ := signature["signatures"].([]any)
+	if len(publicKeys) != 3 || len(signatures) != 2 || signature["signatures_required"] != float64(2) {
+		t.Fatalf("wrong submitted signature: %v", signature)
+	}
+}
@@ -0,0 +1,70 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// [GetTableItem] retrieves the value of a key in a [Table].
+//
+// [GetTableItem]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/operations/get_table_item
+func (client *Client) GetTableItem(ctx context.Context, request *GetTableItemRequest) (*AptosResponse[GetTableItemResponse], error) {
+	return doRequestForType[GetTableItemResponse](ctx, client, request)
+}
+
+// GetTableItemRequest is the request for the value of the key in the table with the handle.
+// Key is the json representation of the key, for example, address is a hex string and u64 is a decimal string.
+type GetTableItemRequest struct {
+	Handle    Address      `json:"-" url:"-"`
+	KeyType   *MoveTypeTag `json:"key_type" url:"-"`
+	ValueType *MoveTypeTag `json:"value_type" url:"-"`
+	Key       any          `json:"key" url:"-"`
+
+	LedgerVersion *uint64 `json:"-" url:"ledger_version,omitempty"`
+}
+
+var _ AptosRequest = (*GetTableItemRequest)(nil)
+
+func (r *GetTableItemRequest) Body() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+func (r *GetTableItemRequest) HttpMethod() string {
+	return http.MethodPost
+}
+
+func (r *GetTableItemRequest) PathSegments() ([]string, error) {
+	if r.Handle.IsZero() {
+		return nil, fmt.Errorf("empty handle for table item request")
+	}
+	if r.KeyType == nil || r.ValueType == nil {
+		return nil, fmt.Errorf("missing key or value type for table item request")
+	}
+
+	return []string{"tables", r.Handle.String(), "item"}, nil
+}
+
+func (r *GetTableItemRequest) IsIdempotent() bool {
+	return true
+}
+
+type GetTableItemResponse = json.RawMessage
+
+// GetTableItemWithType retrieves the value of the key in the table, then marshal it into requested type T.
+//
+// This is a function since golang doesn't support generic method.
+func GetTableItemWithType[T any](ctx context.Context, client *Client, request *GetTableItemRequest) (*T, error) {
+	resp, err := client.GetTableItem(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	if err := json.Unmarshal(*resp.Parsed, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
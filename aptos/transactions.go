@@ -3,6 +3,8 @@ package aptos
 import (
 	"crypto/ed25519"
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/fardream/go-bcs/bcs"
 	"golang.org/x/crypto/sha3"
@@ -29,6 +31,29 @@ type Transaction struct {
 	ChainId uint8 `json:"-"`
 }
 
+var _ bcs.Unmarshaler = (*Transaction)(nil)
+
+// UnmarshalBCS decodes the raw transaction from bcs.
+func (tx *Transaction) UnmarshalBCS(r io.Reader) (int, error) {
+	b := newBcsReader(r)
+	tx.decode(b)
+
+	return b.n, b.err
+}
+
+func (tx *Transaction) decode(b *bcsReader) {
+	b.decode(&tx.Sender)
+	b.decode(&tx.SequenceNumber)
+	tx.Payload = &TransactionPayload{}
+	if b.err == nil {
+		tx.Payload.decode(b)
+	}
+	b.decode(&tx.MaxGasAmount)
+	b.decode(&tx.GasUnitPrice)
+	b.decode(&tx.ExpirationTimestampSecs)
+	b.decode(&tx.ChainId)
+}
+
 // rawTransactionPrefix is sha3-256 of "APTOS::RawTransaction"
 var rawTransactionPrefix []byte = sha3_Sum256Slice([]byte("APTOS::RawTransaction"))
 
@@ -76,14 +101,11 @@ func EncodeTransaction(tx *Transaction) []byte {
 	return append(rawTransactionPrefix, txBytes...)
 }
 
-// GetHash get the hash of the transaction that can be used to look up the transaction on chain.
+// GetHash get the sha3-256 of the signing bytes of the transaction.
 //
-// Hash of the transaction is sha3-256 of ("RawTransaction" | bcs encoded transaction).
-// BCS encoded transaction can be obtained by [Transaction.ToBCS] method.
+// This is not the hash of the transaction on chain, which is calculated from the transaction and its authenticator.
 //
-// See [here].
-//
-// [here]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/operations/get_transaction_by_hash
+// Deprecated: Use [SignedTransaction.GetHash] to get the hash that can be used to look up the transaction on chain.
 func (tx *Transaction) GetHash() []byte {
 	signingBytes := EncodeTransaction(tx)
 	// prefixBytes := []byte("RawTransaction")
@@ -92,16 +114,121 @@ func (tx *Transaction) GetHash() []byte {
 	return hashed[:]
 }
 
-// SingleSignature
+// SingleSignature is the signature of the sender, which can be an ed25519 signature, a multi ed25519 signature,
+// a single key signature, or a multi key signature.
+//
+// For ed25519 signature, PublicKey and Signature are set.
+// For multi ed25519 signature, PublicKeys, Signatures, Threshold, and Bitmap are set, see [MultiEd25519Account].
+// For single key signature, SingleKey is set, see [SingleKeyAccount].
+// For multi key signature, MultiKey is set, see [MultiKeyAccount].
 type SingleSignature struct {
 	Type      string `json:"type"`
-	PublicKey string `json:"public_key"`
-	Signature string `json:"signature"`
+	PublicKey string `json:"public_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+
+	PublicKeys []string `json:"public_keys,omitempty"`
+	Signatures []string `json:"signatures,omitempty"`
+	Threshold  uint8    `json:"threshold,omitempty"`
+	Bitmap     string   `json:"bitmap,omitempty"`
+
+	SingleKey *SingleKeyAuthenticator `json:"-"`
+	MultiKey  *MultiKeyAuthenticator  `json:"-"`
+}
+
+var (
+	_ json.Marshaler   = (*SingleSignature)(nil)
+	_ json.Unmarshaler = (*SingleSignature)(nil)
+)
+
+// singleSignature_Plain has the same fields as [SingleSignature] without the json methods.
+type singleSignature_Plain SingleSignature
+
+// singleKeySignatureJson is the json format of [SingleKeySignatureType].
+type singleKeySignatureJson struct {
+	Type string `json:"type"`
+	*SingleKeyAuthenticator
+}
+
+// MarshalJSON writes single key and multi key signatures as [SingleSenderSignatureType], which is the transaction signature
+// the rest api accepts for those accounts.
+func (s SingleSignature) MarshalJSON() ([]byte, error) {
+	switch s.Type {
+	case SingleKeySignatureType:
+		if s.SingleKey == nil {
+			return nil, fmt.Errorf("single key signature is not set")
+		}
+		return json.Marshal(singleKeySignatureJson{Type: SingleSenderSignatureType, SingleKeyAuthenticator: s.SingleKey})
+	case MultiKeySignatureType:
+		if s.MultiKey == nil {
+			return nil, fmt.Errorf("multi key signature is not set")
+		}
+		r, err := newMultiKeySignatureJson(s.MultiKey)
+		if err != nil {
+			return nil, err
+		}
+		r.Type = SingleSenderSignatureType
+		return json.Marshal(r)
+	default:
+		return json.Marshal(singleSignature_Plain(s))
+	}
+}
+
+func (s *SingleSignature) UnmarshalJSON(data []byte) error {
+	var signatureType struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &signatureType); err != nil {
+		return err
+	}
+
+	if signatureType.Type == SingleSenderSignatureType {
+		var multiKey struct {
+			PublicKeys json.RawMessage `json:"public_keys"`
+		}
+		if err := json.Unmarshal(data, &multiKey); err != nil {
+			return err
+		}
+		if multiKey.PublicKeys != nil {
+			signatureType.Type = MultiKeySignatureType
+		} else {
+			signatureType.Type = SingleKeySignatureType
+		}
+	}
+
+	*s = SingleSignature{Type: signatureType.Type}
+
+	switch signatureType.Type {
+	case SingleKeySignatureType:
+		s.SingleKey = &SingleKeyAuthenticator{}
+		return json.Unmarshal(data, s.SingleKey)
+	case MultiKeySignatureType:
+		var r multiKeySignatureJson
+		if err := json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+		s.MultiKey = r.toAuthenticator()
+		return nil
+	default:
+		return json.Unmarshal(data, (*singleSignature_Plain)(s))
+	}
 }
 
 // Ed25519SinatureType is the signature type for single signer based on a public/private key of ed25519 type.
 const Ed25519SignatureType = "ed25519_signature"
 
+// MultiEd25519SignatureType is the signature type for K-of-N signers of ed25519 type.
+const MultiEd25519SignatureType = "multi_ed25519_signature"
+
+// SingleKeySignatureType is the signature type for a single signer of any key scheme.
+const SingleKeySignatureType = "single_key_signature"
+
+// MultiKeySignatureType is the signature type for K-of-N signers of any key scheme.
+const MultiKeySignatureType = "multi_key_signature"
+
+// SingleSenderSignatureType is the type of the transaction signature in json for the single key and multi key accounts,
+// the fields of the single key or multi key signature are inlined.
+const SingleSenderSignatureType = "single_sender"
+
 // 64 zero bytes
 const simulationSignature = "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
 
@@ -123,6 +250,27 @@ func NewSingleSignatureForSimulation(publicKey *ed25519.PublicKey) *SingleSignat
 	}
 }
 
+// isSimulation checks if all the signatures are all zero, as required by simulation.
+func (s *SingleSignature) isSimulation() bool {
+	switch s.Type {
+	case Ed25519SignatureType:
+		return s.Signature == simulationSignature
+	case MultiEd25519SignatureType:
+		for _, sig := range s.Signatures {
+			if sig != simulationSignature {
+				return false
+			}
+		}
+		return len(s.Signatures) > 0
+	case SingleKeySignatureType:
+		return s.SingleKey != nil && s.SingleKey.Signature.isSimulation()
+	case MultiKeySignatureType:
+		return s.MultiKey != nil && s.MultiKey.isSimulation()
+	default:
+		return false
+	}
+}
+
 // TransactionInfo contains the information about the transaction that has been submitted to the blockchain.
 type TransactionInfo struct {
 	// Hash of the transaction.
@@ -10,6 +10,8 @@ import (
 )
 
 // FillTransactionData fills the missing data for a transaction.
+// Gas unit price is taken from the cached gas estimate with the priority set by [ClientOption_GasPriority],
+// and the cache is refreshed if it is missing or expired.
 // seqNumIsZero indicates the sequence number is 0 for the account and therefore doesn't need to check
 func (client *Client) FillTransactionData(ctx context.Context, tx *Transaction, seqNumIsZero bool) error {
 	// check the sequence number
@@ -29,24 +31,24 @@ func (client *Client) FillTransactionData(ctx context.Context, tx *Transaction,
 		}
 	}
 
-	if tx.GasUnitPrice == 0 {
-		if client.gasEstimate == nil {
-			if err := client.RefreshData(ctx); err != nil {
-				return err
-			}
-		}
+	client.state.mu.RLock()
+	needRefresh := tx.GasUnitPrice == 0 || (tx.ChainId == 0 && client.state.chainId == 0)
+	client.state.mu.RUnlock()
 
-		tx.GasUnitPrice = JsonUint64(client.gasEstimate.GasEstimate)
+	if needRefresh {
+		if err := client.refreshIfStale(ctx); err != nil {
+			return err
+		}
 	}
 
+	client.state.mu.RLock()
+	if tx.GasUnitPrice == 0 {
+		tx.GasUnitPrice = JsonUint64(client.state.gasEstimate.GasPrice(client.state.gasPriority))
+	}
 	if tx.ChainId == 0 {
-		if client.chainId == 0 {
-			if err := client.RefreshData(ctx); err != nil {
-				return err
-			}
-		}
-		tx.ChainId = client.chainId
+		tx.ChainId = client.state.chainId
 	}
+	client.state.mu.RUnlock()
 
 	client.defaultTransactionOptions.FillIfDefault(tx)
 
@@ -97,13 +99,77 @@ func (r *EncodeSubmissionRequest) PathSegments() ([]string, error) {
 	return []string{"transactions", "encode_submission"}, nil
 }
 
+func (r *EncodeSubmissionRequest) IsIdempotent() bool {
+	return true
+}
+
 type EncodeSubmissionResponse string
 
 // [SubmitTransaction]
 //
+// If the client has a [RetryPolicy], submission failed with a retryable error (see [IsRetryableError]) is retried.
+// Before each resubmission, the client looks up the transaction by its hash (see [SignedTransaction.GetHash]),
+// and returns the transaction found instead of submitting again.
+//
 // [SubmitTransaction]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/operations/submit_transaction
 func (client *Client) SubmitTransaction(ctx context.Context, request *SubmitTransactionRequest) (*AptosResponse[SubmitTransactionResponse], error) {
-	return doRequestForType[SubmitTransactionResponse](ctx, client, request)
+	hash, err := request.GetHashString()
+	if err != nil {
+		// the hash is only used to check if the transaction has landed, continue without it.
+		hash = ""
+	}
+
+	return client.submitTransaction(ctx, request, hash)
+}
+
+// submitTransaction submits the transaction, and retries according to the retry policy of the client.
+// hash of the signed transaction is used to check if the transaction has already been submitted before resubmission.
+func (client *Client) submitTransaction(ctx context.Context, request AptosRequest, hash string) (*AptosResponse[SubmitTransactionResponse], error) {
+	resp, err := doRequestForType[SubmitTransactionResponse](ctx, client, request)
+
+	policy := client.retryPolicy
+	for retry := 1; err != nil && policy != nil && retry <= policy.MaxRetries && IsRetryableError(err); retry++ {
+		if waitErr := policy.wait(ctx, retry, err); waitErr != nil {
+			return nil, err
+		}
+
+		if landed, findErr := client.findSubmittedTransaction(ctx, hash); findErr == nil && landed != nil {
+			return landed, nil
+		}
+
+		resp, err = doRequestForType[SubmitTransactionResponse](ctx, client, request)
+		if err != nil && !IsRetryableError(err) {
+			// the resubmission can be rejected because the previous submission landed in the mean time.
+			if landed, findErr := client.findSubmittedTransaction(ctx, hash); findErr == nil && landed != nil {
+				return landed, nil
+			}
+		}
+	}
+
+	return resp, err
+}
+
+// findSubmittedTransaction looks up the transaction by hash, which can be either pending or committed.
+// nil is returned if such transaction cannot be found.
+func (client *Client) findSubmittedTransaction(ctx context.Context, hash string) (*AptosResponse[SubmitTransactionResponse], error) {
+	if hash == "" {
+		return nil, nil
+	}
+
+	resp, err := client.GetTransactionByHash(ctx, &GetTransactionByHashRequest{Hash: hash})
+	if err != nil {
+		aptosError, ok := err.(*AptosRestError)
+		if ok && aptosError.HttpStatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &AptosResponse[SubmitTransactionResponse]{
+		RawData: resp.RawData,
+		Parsed:  &SubmitTransactionResponse{TransactionWithInfo: resp.Parsed.TransactionWithInfo},
+		Headers: resp.Headers,
+	}, nil
 }
 
 type SubmitTransactionRequest struct {
@@ -125,6 +191,16 @@ func (r *SubmitTransactionRequest) PathSegments() ([]string, error) {
 	return []string{"transactions"}, nil
 }
 
+// GetHashString calculates the hash of the transaction on chain before it is submitted. See [SignedTransaction.GetHash].
+func (r *SubmitTransactionRequest) GetHashString() (string, error) {
+	signedTx, err := NewSignedTransaction(r.Transaction, &r.Signature)
+	if err != nil {
+		return "", err
+	}
+
+	return signedTx.GetHashString()
+}
+
 type SubmitTransactionResponse struct {
 	*TransactionWithInfo `json:",inline"`
 }
@@ -169,16 +245,39 @@ type GetTransactionByVersionResponse struct {
 	*TransactionWithInfo `json:",inline"`
 }
 
+// [GetAccountTransactions] returns the committed transactions sent by the account, starting from a sequence number.
+//
+// [GetAccountTransactions]: https://fullnode.mainnet.aptoslabs.com/v1/spec#/operations/get_account_transactions
+func (client *Client) GetAccountTransactions(ctx context.Context, request *GetAccountTransactionsRequest) (*AptosResponse[GetAccountTransactionsResponse], error) {
+	return doRequestForType[GetAccountTransactionsResponse](ctx, client, request)
+}
+
+type GetAccountTransactionsRequest struct {
+	GetRequest
+
+	Address Address     `url:"-"`
+	Start   *JsonUint64 `url:"start,omitempty"`
+	Limit   *JsonUint64 `url:"limit,omitempty"`
+}
+
+var _ AptosRequest = (*GetAccountTransactionsRequest)(nil)
+
+func (r *GetAccountTransactionsRequest) PathSegments() ([]string, error) {
+	if r.Address.IsZero() {
+		return nil, fmt.Errorf("empty address for account transactions request")
+	}
+
+	return []string{"accounts", r.Address.String(), "transactions"}, nil
+}
+
+type GetAccountTransactionsResponse []*TransactionWithInfo
+
 // [SimulateTransaction]
 //
 // [SimulateTransaction]: https://fullnode.mainnet.aptoslabs.com/v1/transactions/simulate
 func (client *Client) SimulateTransaction(ctx context.Context, request *SimulateTransactionRequest) (*AptosResponse[SimulateTransactionResponse], error) {
-	if len(request.Signature.Signature) != SignatureLength*2+2 {
-		return nil, fmt.Errorf("signature (%s) is not length %d", request.Signature.Signature, SignatureLength)
-	}
-
-	if request.Signature.Signature != simulationSignature {
-		return nil, fmt.Errorf("signagure (%s) is not all zero", request.Signature.Signature)
+	if !request.Signature.isSimulation() {
+		return nil, fmt.Errorf("%s is not all zero", request.Signature.Type)
 	}
 
 	return doRequestForType[SimulateTransactionResponse](ctx, client, request)
@@ -203,6 +302,10 @@ func (request *SimulateTransactionRequest) PathSegments() ([]string, error) {
 	return []string{"transactions", "simulate"}, nil
 }
 
+func (request *SimulateTransactionRequest) IsIdempotent() bool {
+	return true
+}
+
 type SimulateTransactionResponse []struct {
 	*TransactionWithInfo `json:",inline"`
 }
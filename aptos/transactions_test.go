@@ -2,7 +2,6 @@ package aptos_test
 
 import (
 	_ "embed"
-	"encoding/hex"
 	"encoding/json"
 	"testing"
 
@@ -28,40 +27,37 @@ func TestTransactionWithInfo(t *testing.T) {
 	}
 }
 
-func TestTransaction_GetHash(t *testing.T) {
-	// t.Skipf("Transaction_GetHash doesnt mathc the current value from chain")
-	// this is from test in test_data/test_tx_1.json
-	sender := aptos.MustParseAddress("0x767b7442b8547fa5cf50989b9b761760ca6687b83d1c23d3589a5ac8acb50639")
-	config, _ := aptos.GetAuxClientConfig(aptos.Devnet)
-	moduleAddress := aptos.MustParseAddress("0xea383dc2819210e6e427e66b2b6aa064435bf672dc4bdc55018049f0c361d01a")
-	aux, _ := aptos.GetAuxFakeCoinCoinType(moduleAddress, aptos.AuxFakeCoin_AUX)
-	usdc, _ := aptos.GetAuxFakeCoinCoinType(moduleAddress, aptos.AuxFakeCoin_USDC)
-	tx := config.ClobMarket_PlaceOrder(
-		sender,
-		true,
-		aux,
-		usdc,
-		50000000,
-		50000000000,
-		0,
-		bcs.Uint128{},
-		aptos.AuxClobMarketOrderType_Limit,
-		0,
-		false,
-		18446744073709551615,
-		aptos.AuxClobMarketSelfTradeType_CancelPassive,
-		aptos.TransactionOption_GasUnitPrice(100),
-		aptos.TransactionOption_SequenceNumber(2625),
-		aptos.TransactionOption_MaxGasAmount(1718192),
-	)
+func TestTransactionWithInfo_SingleSender(t *testing.T) {
+	data := `{
+  "type": "user_transaction",
+  "hash": "0x01",
+  "sender": "0x2",
+  "sequence_number": "3",
+  "success": true,
+  "signature": {
+    "type": "single_sender",
+    "public_key": {"type": "keyless", "value": "0x1b68747470733a2f2f6163636f756e74732e676f6f676c652e636f6d"},
+    "signature": {"type": "keyless", "value": "0x00"}
+  }
+}`
+	var tx aptos.TransactionWithInfo
+	if err := json.Unmarshal([]byte(data), &tx); err != nil {
+		t.Fatalf("failed to unmarshal transaction: %v", err)
+	}
+	if !tx.Success || tx.SequenceNumber != 3 {
+		t.Fatalf("wrong transaction: %#v", tx)
+	}
+}
 
-	tx.ExpirationTimestampSecs = 1667245112
-	tx.ChainId = 35
+func TestSignedTransaction_GetHash(t *testing.T) {
+	// this is from test in test_data/test_tx_1.json
+	signedTx := testSignedTransaction1(t)
 
-	jsonBytes, _ := json.MarshalIndent(tx, "", "  ")
-	t.Log(string(jsonBytes))
 	expectedHash := "0xb161e7592d5f8ea8a97f3493669660205ee76f8699f20e71ae2ad3878836a1ac"
-	hash := "0x" + hex.EncodeToString(tx.GetHash())
+	hash, err := signedTx.GetHashString()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if hash != expectedHash {
 		t.Fatalf("hash doesn't match:\nwant: %s\nhas:  %s\n", expectedHash, hash)
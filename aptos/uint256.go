@@ -0,0 +1,143 @@
+package aptos
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/fardream/go-bcs/bcs"
+)
+
+// Uint256 is like `u256` in move. It is serialized into a decimal string in json, same as [bcs.Uint128].
+type Uint256 struct {
+	// little endian 64 bit words.
+	words [4]uint64
+}
+
+var (
+	_ json.Marshaler   = (*Uint256)(nil)
+	_ json.Unmarshaler = (*Uint256)(nil)
+	_ bcs.Marshaler    = (*Uint256)(nil)
+	_ bcs.Unmarshaler  = (*Uint256)(nil)
+)
+
+var maxU256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// NewUint256FromUint64 creates a [Uint256] from the four 64 bit words, from the least significant to the most significant.
+func NewUint256FromUint64(w0, w1, w2, w3 uint64) *Uint256 {
+	return &Uint256{words: [4]uint64{w0, w1, w2, w3}}
+}
+
+// NewUint256FromBigInt creates a [Uint256] from [big.Int], which must be between 0 and 2^256-1.
+func NewUint256FromBigInt(bigI *big.Int) (*Uint256, error) {
+	r := &Uint256{}
+	if err := r.SetBigInt(bigI); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewUint256 parses a decimal string into [Uint256].
+func NewUint256(s string) (*Uint256, error) {
+	bigI, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse %s as an integer", s)
+	}
+
+	return NewUint256FromBigInt(bigI)
+}
+
+// SetBigInt sets the value from [big.Int].
+func (i *Uint256) SetBigInt(bigI *big.Int) error {
+	if bigI.Sign() < 0 {
+		return fmt.Errorf("%s is negative", bigI.String())
+	}
+	if bigI.Cmp(maxU256) >= 0 {
+		return fmt.Errorf("%s is greater than Max Uint 256", bigI.String())
+	}
+
+	r := make([]byte, 32)
+	bigI.FillBytes(r)
+	for k := 0; k < 4; k++ {
+		i.words[k] = binary.BigEndian.Uint64(r[32-8*(k+1) : 32-8*k])
+	}
+
+	return nil
+}
+
+// Big converts the value to [big.Int].
+func (i Uint256) Big() *big.Int {
+	r := make([]byte, 32)
+	for k := 0; k < 4; k++ {
+		binary.BigEndian.PutUint64(r[32-8*(k+1):32-8*k], i.words[k])
+	}
+
+	return new(big.Int).SetBytes(r)
+}
+
+func (i Uint256) String() string {
+	return i.Big().String()
+}
+
+// Cmp compares i and j, and returns -1, 0, or 1.
+func (i *Uint256) Cmp(j *Uint256) int {
+	for k := 3; k >= 0; k-- {
+		switch {
+		case i.words[k] > j.words[k]:
+			return 1
+		case i.words[k] < j.words[k]:
+			return -1
+		}
+	}
+
+	return 0
+}
+
+func (i Uint256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+func (i *Uint256) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	return i.UnmarshalText([]byte(str))
+}
+
+func (i *Uint256) UnmarshalText(data []byte) error {
+	bigI, ok := new(big.Int).SetString(string(data), 10)
+	if !ok {
+		return fmt.Errorf("failed to parse %s as an integer", string(data))
+	}
+
+	return i.SetBigInt(bigI)
+}
+
+// MarshalBCS encodes the value as 32 bytes little endian.
+func (i Uint256) MarshalBCS() ([]byte, error) {
+	r := make([]byte, 32)
+	for k := 0; k < 4; k++ {
+		binary.LittleEndian.PutUint64(r[8*k:], i.words[k])
+	}
+
+	return r, nil
+}
+
+func (i *Uint256) UnmarshalBCS(r io.Reader) (int, error) {
+	buf := make([]byte, 32)
+	n, err := io.ReadFull(r, buf)
+	if err != nil {
+		return n, fmt.Errorf("failed to read 32 bytes for Uint256 (read %d bytes): %w", n, err)
+	}
+
+	for k := 0; k < 4; k++ {
+		i.words[k] = binary.LittleEndian.Uint64(buf[8*k:])
+	}
+
+	return n, nil
+}
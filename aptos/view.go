@@ -36,4 +36,17 @@ func (r *ViewRequest) PathSegments() ([]string, error) {
 	return []string{"view"}, nil
 }
 
+func (r *ViewRequest) IsIdempotent() bool {
+	return true
+}
+
 type ViewResponse = json.RawMessage
+
+// ViewBcs calls the view function like [Client.View], but requests the result in bcs.
+// Each element of the response is the bcs encoding of one return value of the function.
+func (client *Client) ViewBcs(ctx context.Context, request *ViewRequest) (*AptosResponse[ViewBcsResponse], error) {
+	return doRequestForTypeWithAccept[ViewBcsResponse](ctx, client, request, ContentTypeBcs)
+}
+
+// ViewBcsResponse contains the bcs encoded return values of a view function.
+type ViewBcsResponse [][]byte
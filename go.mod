@@ -4,6 +4,7 @@ go 1.19
 
 require (
 	github.com/davecgh/go-spew v1.1.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/fardream/go-bcs v0.5.0
 	github.com/goccy/go-yaml v1.12.0
 	github.com/google/go-cmp v0.6.0